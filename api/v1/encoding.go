@@ -0,0 +1,239 @@
+package v1
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Encoding converts IDs to and from strings.
+type Encoding interface {
+	// Name identifies the encoding in the registry.
+	Name() string
+	Encode(id ID) string
+	Decode(s string) (ID, error)
+}
+
+var (
+	// Hex is the lowercase hexadecimal encoding of ID.String, without
+	// leading zeros.
+	Hex Encoding = hexEncoding{}
+
+	// Decimal encodes IDs as unsigned decimal numbers.
+	Decimal Encoding = decimalEncoding{}
+
+	// Base32 is Crockford's base32. Decoding is case insensitive and reads
+	// I and L as 1 and O as 0.
+	Base32 Encoding = newCrockfordEncoding()
+
+	// Base58 uses the Bitcoin alphabet, which leaves out 0, O, I and l.
+	Base58 Encoding = newRadixEncoding("base58", "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+
+	// Base62 uses digits and ASCII letters.
+	Base62 Encoding = newRadixEncoding("base62", "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+
+	// Base64URL is the unpadded URL safe base64 of the 8 big-endian bytes
+	// of an ID.
+	Base64URL Encoding = base64URLEncoding{}
+)
+
+var encodings = struct {
+	sync.RWMutex
+	m map[string]Encoding
+}{m: make(map[string]Encoding)}
+
+func init() {
+	for _, enc := range []Encoding{Hex, Decimal, Base32, Base58, Base62, Base64URL} {
+		RegisterEncoding(enc)
+	}
+}
+
+// RegisterEncoding makes enc available by its name through LookupEncoding.
+// It replaces any encoding registered under the same name.
+func RegisterEncoding(enc Encoding) {
+	encodings.Lock()
+	defer encodings.Unlock()
+	encodings.m[strings.ToLower(enc.Name())] = enc
+}
+
+// LookupEncoding returns the encoding registered under name, ignoring case.
+func LookupEncoding(name string) (Encoding, error) {
+	encodings.RLock()
+	defer encodings.RUnlock()
+	if enc, ok := encodings.m[strings.ToLower(name)]; ok {
+		return enc, nil
+	}
+	return nil, fmt.Errorf("unknown ID encoding %q; must be one of %s", name, strings.Join(encodingNames(), ", "))
+}
+
+// encodingNames lists the registered encodings. encodings must be locked.
+func encodingNames() []string {
+	names := make([]string, 0, len(encodings.m))
+	for name := range encodings.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Format returns id encoded with enc.
+func (id ID) Format(enc Encoding) string {
+	return enc.Encode(id)
+}
+
+// ParseID parses an ID encoded with enc.
+func ParseID(s string, enc Encoding) (ID, error) {
+	return enc.Decode(s)
+}
+
+// encodedID is an ID whose JSON is a string encoded with enc.
+type encodedID struct {
+	id  ID
+	enc Encoding
+}
+
+func (e encodedID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + e.enc.Encode(e.id) + `"`), nil
+}
+
+// UnmarshalJSON also accepts the string without quotes.
+func (e *encodedID) UnmarshalJSON(data []byte) error {
+	str := string(data)
+	if l := len(str); l > 2 && str[0] == '"' && str[l-1] == '"' {
+		str = str[1 : l-1]
+	}
+	id, err := e.enc.Decode(str)
+	if err != nil {
+		return err
+	}
+
+	e.id = id
+	return nil
+}
+
+type hexEncoding struct{}
+
+func (hexEncoding) Name() string                { return "hex" }
+func (hexEncoding) Encode(id ID) string         { return id.String() }
+func (hexEncoding) Decode(s string) (ID, error) { return NewIDFromString(s) }
+
+type decimalEncoding struct{}
+
+func (decimalEncoding) Name() string { return "decimal" }
+
+func (decimalEncoding) Encode(id ID) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func (decimalEncoding) Decode(s string) (ID, error) {
+	id, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return ID(0), err
+	}
+
+	return ID(id), nil
+}
+
+// radixEncoding writes IDs as fixed width numbers in the base of its
+// alphabet. The alphabets are in ASCII order, so the strings sort like the
+// IDs they encode.
+type radixEncoding struct {
+	name     string
+	alphabet string
+	base     uint64
+	width    int
+	digits   [256]int8
+}
+
+func newRadixEncoding(name, alphabet string) *radixEncoding {
+	enc := &radixEncoding{name: name, alphabet: alphabet, base: uint64(len(alphabet))}
+	for n := ^uint64(0); n > 0; n /= enc.base {
+		enc.width++
+	}
+	for i := range enc.digits {
+		enc.digits[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		enc.digits[alphabet[i]] = int8(i)
+	}
+	return enc
+}
+
+// newCrockfordEncoding creates Crockford's base32, which is case insensitive
+// and reads the letters that look like a digit as that digit.
+func newCrockfordEncoding() *radixEncoding {
+	enc := newRadixEncoding("base32", "0123456789ABCDEFGHJKMNPQRSTVWXYZ")
+	for i := 0; i < len(enc.alphabet); i++ {
+		if c := enc.alphabet[i]; c >= 'A' && c <= 'Z' {
+			enc.digits[c+'a'-'A'] = int8(i)
+		}
+	}
+	for _, c := range "IiLl" {
+		enc.digits[c] = 1
+	}
+	for _, c := range "Oo" {
+		enc.digits[c] = 0
+	}
+	return enc
+}
+
+func (enc *radixEncoding) Name() string { return enc.name }
+
+func (enc *radixEncoding) Encode(id ID) string {
+	b := make([]byte, enc.width)
+	v := uint64(id)
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = enc.alphabet[v%enc.base]
+		v /= enc.base
+	}
+	return string(b)
+}
+
+// Decode also accepts strings without the leading zero digits.
+func (enc *radixEncoding) Decode(s string) (ID, error) {
+	if s == "" || len(s) > enc.width {
+		return ID(0), fmt.Errorf("invalid %s ID %q: must be 1 to %d characters", enc.name, s, enc.width)
+	}
+	var v uint64
+	for i := 0; i < len(s); i++ {
+		d := enc.digits[s[i]]
+		if d < 0 {
+			return ID(0), fmt.Errorf("invalid %s ID %q: unexpected character %q", enc.name, s, s[i])
+		}
+		hi, lo := bits.Mul64(v, enc.base)
+		lo, carry := bits.Add64(lo, uint64(d), 0)
+		if hi != 0 || carry != 0 {
+			return ID(0), fmt.Errorf("invalid %s ID %q: out of range", enc.name, s)
+		}
+		v = lo
+	}
+
+	return ID(v), nil
+}
+
+type base64URLEncoding struct{}
+
+func (base64URLEncoding) Name() string { return "base64url" }
+
+func (base64URLEncoding) Encode(id ID) string {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(id))
+	return base64.RawURLEncoding.EncodeToString(b[:])
+}
+
+func (base64URLEncoding) Decode(s string) (ID, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return ID(0), fmt.Errorf("invalid base64url ID %q: %v", s, err)
+	}
+	if len(b) != 8 {
+		return ID(0), fmt.Errorf("invalid base64url ID %q: must encode 8 bytes", s)
+	}
+
+	return ID(binary.BigEndian.Uint64(b)), nil
+}
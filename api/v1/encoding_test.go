@@ -0,0 +1,125 @@
+package v1
+
+import (
+	"encoding/json"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// encodingTestIDs are the edge cases of the encodings, and random IDs.
+func encodingTestIDs() []ID {
+	ids := []ID{0, 1, 9, 10, 31, 32, 57, 58, 61, 62, 0x7fffffffffffffff, 0x8000000000000000, 0xffffffffffffffff}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, ID(r.Uint64()>>uint(r.Intn(64))))
+	}
+	return ids
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	encodings.RLock()
+	names := encodingNames()
+	encodings.RUnlock()
+	for _, name := range names {
+		enc, err := LookupEncoding(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range encodingTestIDs() {
+			s := enc.Encode(id)
+			got, err := enc.Decode(s)
+			if err != nil {
+				t.Fatalf("%s: Decode(%q): %v", name, s, err)
+			}
+			if got != id {
+				t.Fatalf("%s: Decode(%q) = %d, want %d", name, s, got, id)
+			}
+
+			// and through the JSON of the messages.
+			data, err := NewSnowflake(id).MarshalJSONEncoding(enc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var x Snowflake
+			if err := x.UnmarshalJSONEncoding(data, enc); err != nil {
+				t.Fatalf("%s: UnmarshalJSONEncoding(%s): %v", name, data, err)
+			}
+			if got, _ := x.ID(); got != id {
+				t.Fatalf("%s: UnmarshalJSONEncoding(%s) = %d, want %d", name, data, got, id)
+			}
+		}
+		if _, err := enc.Decode(""); err == nil {
+			t.Errorf("%s: Decode of the empty string succeeded", name)
+		}
+	}
+}
+
+func TestEncodingSortsLikeIDs(t *testing.T) {
+	for _, enc := range []Encoding{Base32, Base58, Base62} {
+		ids := encodingTestIDs()
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		width := len(enc.Encode(0))
+		for i, id := range ids {
+			s := enc.Encode(id)
+			if len(s) != width {
+				t.Fatalf("%s: Encode(%d) = %q, want %d characters", enc.Name(), id, s, width)
+			}
+			if i > 0 && id != ids[i-1] && s <= enc.Encode(ids[i-1]) {
+				t.Fatalf("%s: Encode(%d) = %q sorts before Encode(%d) = %q", enc.Name(), id, s, ids[i-1], enc.Encode(ids[i-1]))
+			}
+		}
+		// leading zeros may be left out.
+		if got, err := enc.Decode(strings.TrimLeft(enc.Encode(12345), enc.Encode(0)[:1])); err != nil || got != 12345 {
+			t.Errorf("%s: Decode without leading zeros = %d, %v; want 12345", enc.Name(), got, err)
+		}
+		if _, err := enc.Decode(enc.Encode(1) + "0"); err == nil {
+			t.Errorf("%s: Decode of %d characters succeeded", enc.Name(), width+1)
+		}
+	}
+}
+
+func TestCrockfordAliases(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		want ID
+	}{
+		{"1O", 32},
+		{"1o", 32},
+		{"I0", 32},
+		{"i0", 32},
+		{"L0", 32},
+		{"l0", 32},
+		{"zz", 1023},
+		{"ZZ", 1023},
+	} {
+		got, err := Base32.Decode(test.s)
+		if err != nil || got != test.want {
+			t.Errorf("Base32.Decode(%q) = %d, %v; want %d", test.s, got, err, test.want)
+		}
+	}
+	for _, s := range []string{"U", "u", "-", "0\x00"} {
+		if _, err := Base32.Decode(s); err == nil {
+			t.Errorf("Base32.Decode(%q) succeeded", s)
+		}
+	}
+}
+
+func TestIDJSONIsHex(t *testing.T) {
+	id := ID(0x0123456789abcdef)
+	data, err := json.Marshal(map[string][]ID{"ids": {id}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"ids":["123456789abcdef"]}`; string(data) != want {
+		t.Errorf("json.Marshal = %s, want %s", data, want)
+	}
+	list, err := NewSnowflakeList([]ID{id}).MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(list) != string(data) {
+		t.Errorf("SnowflakeList.MarshalJSON = %s, want %s", list, data)
+	}
+}
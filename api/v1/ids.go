@@ -4,8 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"strconv"
-
-	"github.com/gogo/protobuf/jsonpb"
+	"time"
 )
 
 // ID returned in GRPC
@@ -29,40 +28,67 @@ func (id ID) String() string {
 	return fmt.Sprintf("%x", uint64(id))
 }
 
-// Size returns the size of this datum in protobuf. It is always 8 bytes.
-func (id *ID) Size() int {
-	return 8
+// Parts are the fields of an ID.
+type Parts struct {
+	// Time is when the ID was created, with millisecond precision.
+	Time time.Time
+	// Datacenter is zero for layouts without a datacenter field.
+	Datacenter int
+	// Machine is the machine within the datacenter.
+	Machine  int
+	Sequence int
 }
 
-// MarshalTo converts ID into a binary representation. Called by protobuf serialization.
-func (id *ID) MarshalTo(data []byte) (n int, err error) {
-	var b [8]byte
-	binary.BigEndian.PutUint64(b[:], uint64(*id))
-	return marshalBytes(data, b[:])
+// Parts breaks id into its fields according to the layout and epoch of the
+// generator that issued it.
+func (id ID) Parts(layout Layout, epoch time.Time) Parts {
+	v := uint64(id)
+	seq := v & (1<<layout.SequenceBits - 1)
+	v >>= layout.SequenceBits
+	machine := v & (1<<layout.MachineBits - 1)
+	v >>= layout.MachineBits
+	datacenter := v & (1<<layout.DatacenterBits - 1)
+	v >>= layout.DatacenterBits
+	ms := v & (1<<layout.TimeBits - 1)
+	return Parts{
+		Time:       epoch.Add(time.Duration(ms) * time.Millisecond),
+		Datacenter: int(datacenter),
+		Machine:    int(machine),
+		Sequence:   int(seq),
+	}
 }
 
-// Unmarshal inflates ID from a binary representation. Called by protobuf serialization.
+// Unmarshal inflates ID from its 8 big-endian bytes.
 func (id *ID) Unmarshal(data []byte) error {
 	if len(data) != 8 {
-		return fmt.Errorf("buffer is too short")
+		return fmt.Errorf("invalid ID buffer of %d bytes, must be 8", len(data))
 	}
 	*id = ID(binary.BigEndian.Uint64(data))
 	return nil
 }
 
-// MarshalJSON converts id into a string enclosed in quotes.
-func (id ID) MarshalJSON() ([]byte, error) {
-	s := fmt.Sprintf(`"%s"`, id.String())
-	return []byte(s), nil
+// MarshalBinary implements encoding.BinaryMarshaler with the 8 big-endian
+// bytes of ID.Bytes.
+func (id ID) MarshalBinary() ([]byte, error) {
+	return id.Bytes(), nil
 }
 
-// UnmarshalJSON inflates id from base64 string, possibly enclosed in quotes.
-func (id *ID) UnmarshalJSON(data []byte) error {
-	str := string(data)
-	if l := len(str); l > 2 && str[0] == '"' && str[l-1] == '"' {
-		str = str[1 : l-1]
-	}
-	nid, err := NewIDFromString(str)
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (id *ID) UnmarshalBinary(data []byte) error {
+	return id.Unmarshal(data)
+}
+
+// MarshalText implements encoding.TextMarshaler with the hex string of
+// ID.String. It makes IDs usable as JSON map keys, in YAML, CSV or with
+// flag.TextVar.
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the string with
+// NewIDFromString.
+func (id *ID) UnmarshalText(text []byte) error {
+	nid, err := NewIDFromString(string(text))
 	if err != nil {
 		return err
 	}
@@ -71,18 +97,20 @@ func (id *ID) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// UnmarshalJSONPB inflates id from base64 string, possibly enclosed in quotes.
-// User by protobuf JSON serialization.
-//
-// TODO: can be removed once this ticket is fixed:
-//       https://github.com/gogo/protobuf/issues/411#issuecomment-393856837
-func (id *ID) UnmarshalJSONPB(_ *jsonpb.Unmarshaler, b []byte) error {
-	return id.UnmarshalJSON(b)
+// MarshalJSON converts id into its hex string enclosed in quotes. The HTTP
+// gateway of the server may encode IDs otherwise, see
+// Snowflake.MarshalJSONEncoding.
+func (id ID) MarshalJSON() ([]byte, error) {
+	return encodedID{id, Hex}.MarshalJSON()
 }
 
-func marshalBytes(dst []byte, src []byte) (n int, err error) {
-	if len(dst) < len(src) {
-		return 0, fmt.Errorf("buffer is too short")
+// UnmarshalJSON inflates id from a hex string, possibly enclosed in quotes.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	e := encodedID{enc: Hex}
+	if err := e.UnmarshalJSON(data); err != nil {
+		return err
 	}
-	return copy(dst, src), nil
+
+	*id = e.id
+	return nil
 }
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// FuzzID checks that every ID survives its text and binary encodings and
+// NewIDFromString, and that any buffer but 8 bytes is refused with its size.
+func FuzzID(f *testing.F) {
+	f.Add(uint64(0), []byte{})
+	f.Add(uint64(1), []byte{1, 2, 3, 4, 5, 6, 7})
+	f.Add(uint64(0x7fffffffffffffff), []byte{1, 2, 3, 4, 5, 6, 7, 8})
+	f.Add(uint64(0xffffffffffffffff), []byte{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	f.Add(uint64(0x0123456789abcdef), bytes.Repeat([]byte{0xff}, 16))
+
+	f.Fuzz(func(t *testing.T, v uint64, data []byte) {
+		id := ID(v)
+
+		text, err := id.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(%d): %v", v, err)
+		}
+		var fromText ID
+		if err := fromText.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q): %v", text, err)
+		}
+		if fromText != id {
+			t.Fatalf("UnmarshalText(%q) = %d, want %d", text, fromText, id)
+		}
+
+		bin, err := id.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(%d): %v", v, err)
+		}
+		var fromBinary ID
+		if err := fromBinary.UnmarshalBinary(bin); err != nil {
+			t.Fatalf("UnmarshalBinary(%x): %v", bin, err)
+		}
+		if fromBinary != id {
+			t.Fatalf("UnmarshalBinary(%x) = %d, want %d", bin, fromBinary, id)
+		}
+
+		fromString, err := NewIDFromString(id.String())
+		if err != nil {
+			t.Fatalf("NewIDFromString(%q): %v", id.String(), err)
+		}
+		if fromString != id {
+			t.Fatalf("NewIDFromString(%q) = %d, want %d", id.String(), fromString, id)
+		}
+
+		var got ID
+		err = got.UnmarshalBinary(data)
+		if len(data) != 8 {
+			want := fmt.Sprintf("invalid ID buffer of %d bytes, must be 8", len(data))
+			if err == nil || err.Error() != want {
+				t.Fatalf("UnmarshalBinary of %d bytes: got error %v, want %q", len(data), err, want)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("UnmarshalBinary(%x): %v", data, err)
+		}
+		if !bytes.Equal(got.Bytes(), data) {
+			t.Fatalf("UnmarshalBinary(%x) = %x", data, got.Bytes())
+		}
+	})
+}
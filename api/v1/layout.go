@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Layout describes how the bits of an ID are split between the timestamp,
+// the node (datacenter and machine) and the per-millisecond sequence. The
+// fields are laid out from the most significant bit: time, datacenter,
+// machine, sequence. A layout filling 63 bits leaves the sign bit unused, so
+// its IDs always fit a signed 64 bit integer, e.g. a SQL BIGINT.
+type Layout struct {
+	TimeBits       uint
+	DatacenterBits uint
+	MachineBits    uint
+	SequenceBits   uint
+}
+
+var (
+	// DefaultLayout is the original snowman split: 42 bits of milliseconds,
+	// 10 bits of machine and 12 bits of sequence.
+	DefaultLayout = Layout{TimeBits: 42, MachineBits: 10, SequenceBits: 12}
+
+	// SonyflakeLayout favours many machines over a high per-node throughput.
+	SonyflakeLayout = Layout{TimeBits: 39, MachineBits: 16, SequenceBits: 8}
+
+	// TwitterLayout is the classic snowflake split with a datacenter field.
+	// It leaves the sign bit unused.
+	TwitterLayout = Layout{TimeBits: 41, DatacenterBits: 5, MachineBits: 5, SequenceBits: 12}
+)
+
+var namedLayouts = map[string]Layout{
+	"default":   DefaultLayout,
+	"sonyflake": SonyflakeLayout,
+	"twitter":   TwitterLayout,
+}
+
+// ParseLayout parses a layout either by name (default, sonyflake, twitter)
+// or as slash separated bit widths: "time/machine/sequence" or
+// "time/datacenter/machine/sequence".
+func ParseLayout(s string) (Layout, error) {
+	if l, ok := namedLayouts[strings.ToLower(s)]; ok {
+		return l, nil
+	}
+	parts := strings.Split(s, "/")
+	if len(parts) != 3 && len(parts) != 4 {
+		return Layout{}, fmt.Errorf("invalid layout %q: expected time/machine/sequence or time/datacenter/machine/sequence", s)
+	}
+	bits := make([]uint, len(parts))
+	for i, p := range parts {
+		n, err := strconv.ParseUint(strings.TrimSpace(p), 10, 8)
+		if err != nil {
+			return Layout{}, fmt.Errorf("invalid layout %q: %v", s, err)
+		}
+		bits[i] = uint(n)
+	}
+	var l Layout
+	if len(bits) == 3 {
+		l = Layout{TimeBits: bits[0], MachineBits: bits[1], SequenceBits: bits[2]}
+	} else {
+		l = Layout{TimeBits: bits[0], DatacenterBits: bits[1], MachineBits: bits[2], SequenceBits: bits[3]}
+	}
+	return l, l.Validate()
+}
+
+// Validate checks that the layout fills 63 or 64 bits and that every field
+// that must exist has at least one bit.
+func (l Layout) Validate() error {
+	if l.TimeBits == 0 {
+		return fmt.Errorf("invalid layout %s: time bits can't be zero", l)
+	}
+	if l.SequenceBits == 0 {
+		return fmt.Errorf("invalid layout %s: sequence bits can't be zero", l)
+	}
+	if l.NodeBits() >= 32 {
+		return fmt.Errorf("invalid layout %s: datacenter and machine bits must be less than 32", l)
+	}
+	if total := l.TimeBits + l.NodeBits() + l.SequenceBits; total != 63 && total != 64 {
+		return fmt.Errorf("invalid layout %s: fields sum to %d bits, must be 63 or 64", l, total)
+	}
+	return nil
+}
+
+// NodeBits returns the number of bits used to identify a node, that is the
+// datacenter and the machine together.
+func (l Layout) NodeBits() uint {
+	return l.DatacenterBits + l.MachineBits
+}
+
+// MaxMachineID returns the largest machine ID of a generator for this
+// layout. It covers both the datacenter and the machine field.
+func (l Layout) MaxMachineID() int {
+	return 1<<l.NodeBits() - 1
+}
+
+// NodeID combines a datacenter and a machine into the machine ID of a
+// generator.
+func (l Layout) NodeID(datacenter, machine int) (int, error) {
+	if datacenter < 0 || datacenter >= 1<<l.DatacenterBits {
+		return 0, fmt.Errorf("invalid datacenter id; must be 0 ≤ id < %d", 1<<l.DatacenterBits)
+	}
+	if machine < 0 || machine >= 1<<l.MachineBits {
+		return 0, fmt.Errorf("invalid machine id; must be 0 ≤ id < %d", 1<<l.MachineBits)
+	}
+	return datacenter<<l.MachineBits | machine, nil
+}
+
+// String returns the layout in the form accepted by ParseLayout.
+func (l Layout) String() string {
+	if l.DatacenterBits == 0 {
+		return fmt.Sprintf("%d/%d/%d", l.TimeBits, l.MachineBits, l.SequenceBits)
+	}
+	return fmt.Sprintf("%d/%d/%d/%d", l.TimeBits, l.DatacenterBits, l.MachineBits, l.SequenceBits)
+}
+
+// Proto converts the layout to its protobuf message.
+func (l Layout) Proto() *IDLayout {
+	return &IDLayout{
+		TimeBits:       uint32(l.TimeBits),
+		DatacenterBits: uint32(l.DatacenterBits),
+		MachineBits:    uint32(l.MachineBits),
+		SequenceBits:   uint32(l.SequenceBits),
+	}
+}
+
+// Layout converts the protobuf message back to a Layout.
+func (m *IDLayout) Layout() Layout {
+	return Layout{
+		TimeBits:       uint(m.GetTimeBits()),
+		DatacenterBits: uint(m.GetDatacenterBits()),
+		MachineBits:    uint(m.GetMachineBits()),
+		SequenceBits:   uint(m.GetSequenceBits()),
+	}
+}
+
+// Parts breaks id into its fields according to the layout and epoch of the
+// server described by m.
+func (m *ServerInfo) Parts(id ID) Parts {
+	return id.Parts(m.GetLayout().Layout(), m.GetEpoch().AsTime())
+}
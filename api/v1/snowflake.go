@@ -0,0 +1,182 @@
+package v1
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MaxNextIDsLength is the largest batch a NextIDs call returns, whether it
+// is served remotely or in-process; servers may cap it lower.
+const MaxNextIDsLength = 1000
+
+// The v1 messages carry IDs as 8 big-endian bytes. The helpers below convert
+// them from and to ID, and give the messages holding IDs a JSON encoding
+// with the IDs as strings of an Encoding, hex by default, rather than the
+// base64 of protobuf JSON.
+
+// Bytes returns the 8 big-endian bytes of id, as held by Snowflake.Id and
+// SnowflakeRange.Start.
+func (id ID) Bytes() []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(id))
+	return b
+}
+
+// IDFromBytes reads an ID from its 8 big-endian bytes.
+func IDFromBytes(b []byte) (ID, error) {
+	var id ID
+	if err := id.Unmarshal(b); err != nil {
+		return ID(0), err
+	}
+
+	return id, nil
+}
+
+// NewSnowflake creates the message carrying id.
+func NewSnowflake(id ID) *Snowflake {
+	return &Snowflake{Id: id.Bytes()}
+}
+
+// ID returns the ID carried by x.
+func (x *Snowflake) ID() (ID, error) {
+	return IDFromBytes(x.GetId())
+}
+
+// MarshalJSON encodes x with the ID as a hex string.
+func (x *Snowflake) MarshalJSON() ([]byte, error) {
+	return x.MarshalJSONEncoding(Hex)
+}
+
+// MarshalJSONEncoding encodes x with the ID as a string encoded with enc.
+func (x *Snowflake) MarshalJSONEncoding(enc Encoding) ([]byte, error) {
+	id, err := x.ID()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		ID encodedID `json:"id"`
+	}{encodedID{id, enc}})
+}
+
+// UnmarshalJSON decodes x from the JSON of MarshalJSON.
+func (x *Snowflake) UnmarshalJSON(data []byte) error {
+	return x.UnmarshalJSONEncoding(data, Hex)
+}
+
+// UnmarshalJSONEncoding decodes x from the JSON of MarshalJSONEncoding with
+// enc.
+func (x *Snowflake) UnmarshalJSONEncoding(data []byte, enc Encoding) error {
+	v := struct {
+		ID encodedID `json:"id"`
+	}{encodedID{enc: enc}}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	x.Id = v.ID.id.Bytes()
+	return nil
+}
+
+// NewSnowflakeRange creates the message of a range of count IDs from start.
+func NewSnowflakeRange(start ID, count int) *SnowflakeRange {
+	return &SnowflakeRange{Start: start.Bytes(), Count: int32(count)}
+}
+
+// StartID returns the first ID of the range.
+func (x *SnowflakeRange) StartID() (ID, error) {
+	return IDFromBytes(x.GetStart())
+}
+
+// MarshalJSON encodes x with the start ID as a hex string.
+func (x *SnowflakeRange) MarshalJSON() ([]byte, error) {
+	return x.MarshalJSONEncoding(Hex)
+}
+
+// MarshalJSONEncoding encodes x with the start ID as a string encoded with
+// enc.
+func (x *SnowflakeRange) MarshalJSONEncoding(enc Encoding) ([]byte, error) {
+	start, err := x.StartID()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Start encodedID `json:"start"`
+		Count int32     `json:"count"`
+	}{encodedID{start, enc}, x.GetCount()})
+}
+
+// MarshalJSON encodes x with the start IDs as hex strings.
+func (x *SnowflakeRanges) MarshalJSON() ([]byte, error) {
+	return x.MarshalJSONEncoding(Hex)
+}
+
+// MarshalJSONEncoding encodes x with the start IDs as strings encoded with
+// enc.
+func (x *SnowflakeRanges) MarshalJSONEncoding(enc Encoding) ([]byte, error) {
+	ranges := make([]json.RawMessage, len(x.GetRanges()))
+	for i, r := range x.GetRanges() {
+		b, err := r.MarshalJSONEncoding(enc)
+		if err != nil {
+			return nil, err
+		}
+		ranges[i] = b
+	}
+	return json.Marshal(struct {
+		Ranges []json.RawMessage `json:"ranges"`
+	}{ranges})
+}
+
+// NewSnowflakeList creates the message carrying ids.
+func NewSnowflakeList(ids []ID) *SnowflakeList {
+	list := &SnowflakeList{Ids: make([]uint64, len(ids))}
+	for i, id := range ids {
+		list.Ids[i] = uint64(id)
+	}
+	return list
+}
+
+// IDs returns the IDs carried by x.
+func (x *SnowflakeList) IDs() []ID {
+	ids := make([]ID, len(x.GetIds()))
+	for i, id := range x.GetIds() {
+		ids[i] = ID(id)
+	}
+	return ids
+}
+
+// MarshalJSON encodes x with the IDs as hex strings.
+func (x *SnowflakeList) MarshalJSON() ([]byte, error) {
+	return x.MarshalJSONEncoding(Hex)
+}
+
+// MarshalJSONEncoding encodes x with the IDs as strings encoded with enc.
+func (x *SnowflakeList) MarshalJSONEncoding(enc Encoding) ([]byte, error) {
+	ids := make([]encodedID, len(x.GetIds()))
+	for i, id := range x.GetIds() {
+		ids[i] = encodedID{ID(id), enc}
+	}
+	return json.Marshal(struct {
+		IDs []encodedID `json:"ids"`
+	}{ids})
+}
+
+// NewSnowflakeParts creates the message of the fields of an ID.
+func NewSnowflakeParts(p Parts) *SnowflakeParts {
+	return &SnowflakeParts{
+		Time:         timestamppb.New(p.Time),
+		DatacenterId: int32(p.Datacenter),
+		MachineId:    int32(p.Machine),
+		Sequence:     int32(p.Sequence),
+	}
+}
+
+// Parts returns the fields of the ID described by x.
+func (x *SnowflakeParts) Parts() Parts {
+	return Parts{
+		Time:       x.GetTime().AsTime(),
+		Datacenter: int(x.GetDatacenterId()),
+		Machine:    int(x.GetMachineId()),
+		Sequence:   int(x.GetSequence()),
+	}
+}
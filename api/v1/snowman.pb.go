@@ -1,653 +1,797 @@
-// Code generated by protoc-gen-gogo. DO NOT EDIT.
-// source: snowman.proto
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: v1/snowman.proto
 
 package v1
 
 import (
-	context "context"
-	fmt "fmt"
-	_ "github.com/gogo/protobuf/gogoproto"
-	proto "github.com/gogo/protobuf/proto"
-	types "github.com/gogo/protobuf/types"
-	golang_proto "github.com/golang/protobuf/proto"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
-	io "io"
-	math "math"
-	math_bits "math/bits"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
 )
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ = proto.Marshal
-var _ = golang_proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
 
 type Snowflake struct {
-	ID                   ID       `protobuf:"bytes,1,opt,name=id,proto3,customtype=ID" json:"id"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id holds the 8 big-endian bytes of the ID, see ID.Bytes.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (m *Snowflake) Reset()         { *m = Snowflake{} }
-func (m *Snowflake) String() string { return proto.CompactTextString(m) }
-func (*Snowflake) ProtoMessage()    {}
-func (*Snowflake) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39c2b57525ee9969, []int{0}
-}
-func (m *Snowflake) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *Snowflake) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_Snowflake.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
+func (x *Snowflake) Reset() {
+	*x = Snowflake{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_snowman_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snowflake) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snowflake) ProtoMessage() {}
+
+func (x *Snowflake) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_snowman_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		return b[:n], nil
+		return ms
 	}
+	return mi.MessageOf(x)
 }
-func (m *Snowflake) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Snowflake.Merge(m, src)
+
+// Deprecated: Use Snowflake.ProtoReflect.Descriptor instead.
+func (*Snowflake) Descriptor() ([]byte, []int) {
+	return file_v1_snowman_proto_rawDescGZIP(), []int{0}
 }
-func (m *Snowflake) XXX_Size() int {
-	return m.Size()
+
+func (x *Snowflake) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
 }
-func (m *Snowflake) XXX_DiscardUnknown() {
-	xxx_messageInfo_Snowflake.DiscardUnknown(m)
+
+type SnowflakeParts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// time is when the ID was created, with millisecond precision.
+	Time *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// datacenter_id is zero for layouts without a datacenter field.
+	DatacenterId int32 `protobuf:"varint,2,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`
+	// machine_id is the machine within the datacenter.
+	MachineId int32 `protobuf:"varint,3,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	Sequence  int32 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
 }
 
-var xxx_messageInfo_Snowflake proto.InternalMessageInfo
+func (x *SnowflakeParts) Reset() {
+	*x = SnowflakeParts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_snowman_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-type BatchIDsRequest struct {
-	Length               int32    `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (x *SnowflakeParts) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *BatchIDsRequest) Reset()         { *m = BatchIDsRequest{} }
-func (m *BatchIDsRequest) String() string { return proto.CompactTextString(m) }
-func (*BatchIDsRequest) ProtoMessage()    {}
-func (*BatchIDsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_39c2b57525ee9969, []int{1}
-}
-func (m *BatchIDsRequest) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *BatchIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_BatchIDsRequest.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
+func (*SnowflakeParts) ProtoMessage() {}
+
+func (x *SnowflakeParts) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_snowman_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		return b[:n], nil
+		return ms
 	}
+	return mi.MessageOf(x)
 }
-func (m *BatchIDsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BatchIDsRequest.Merge(m, src)
-}
-func (m *BatchIDsRequest) XXX_Size() int {
-	return m.Size()
+
+// Deprecated: Use SnowflakeParts.ProtoReflect.Descriptor instead.
+func (*SnowflakeParts) Descriptor() ([]byte, []int) {
+	return file_v1_snowman_proto_rawDescGZIP(), []int{1}
 }
-func (m *BatchIDsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_BatchIDsRequest.DiscardUnknown(m)
+
+func (x *SnowflakeParts) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
 }
 
-var xxx_messageInfo_BatchIDsRequest proto.InternalMessageInfo
+func (x *SnowflakeParts) GetDatacenterId() int32 {
+	if x != nil {
+		return x.DatacenterId
+	}
+	return 0
+}
 
-func (m *BatchIDsRequest) GetLength() int32 {
-	if m != nil {
-		return m.Length
+func (x *SnowflakeParts) GetMachineId() int32 {
+	if x != nil {
+		return x.MachineId
 	}
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*Snowflake)(nil), "snowman.api.v1.Snowflake")
-	golang_proto.RegisterType((*Snowflake)(nil), "snowman.api.v1.Snowflake")
-	proto.RegisterType((*BatchIDsRequest)(nil), "snowman.api.v1.BatchIDsRequest")
-	golang_proto.RegisterType((*BatchIDsRequest)(nil), "snowman.api.v1.BatchIDsRequest")
+func (x *SnowflakeParts) GetSequence() int32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
 }
 
-func init() { proto.RegisterFile("snowman.proto", fileDescriptor_39c2b57525ee9969) }
-func init() { golang_proto.RegisterFile("snowman.proto", fileDescriptor_39c2b57525ee9969) }
+type IDLayout struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var fileDescriptor_39c2b57525ee9969 = []byte{
-	// 270 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2d, 0xce, 0xcb, 0x2f,
-	0xcf, 0x4d, 0xcc, 0xd3, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x83, 0x71, 0x13, 0x0b, 0x32,
-	0xf5, 0xca, 0x0c, 0xa5, 0xa4, 0xd3, 0xf3, 0xf3, 0xd3, 0x73, 0x52, 0xf5, 0xc1, 0xb2, 0x49, 0xa5,
-	0x69, 0xfa, 0xa9, 0xb9, 0x05, 0x25, 0x95, 0x10, 0xc5, 0x52, 0xba, 0xe9, 0x99, 0x25, 0x19, 0xa5,
-	0x49, 0x7a, 0xc9, 0xf9, 0xb9, 0xfa, 0xe9, 0xf9, 0xe9, 0xf9, 0x08, 0x55, 0x20, 0x1e, 0x98, 0x03,
-	0x66, 0x41, 0x94, 0x2b, 0xe9, 0x72, 0x71, 0x06, 0xe7, 0xe5, 0x97, 0xa7, 0xe5, 0x24, 0x66, 0xa7,
-	0x0a, 0x29, 0x70, 0x31, 0x65, 0xa6, 0x48, 0x30, 0x2a, 0x30, 0x6a, 0xf0, 0x38, 0x09, 0x9c, 0xb8,
-	0x27, 0xcf, 0x70, 0xeb, 0x9e, 0x3c, 0x93, 0xa7, 0xcb, 0x23, 0x30, 0x19, 0xc4, 0x94, 0x99, 0xa2,
-	0xa4, 0xc9, 0xc5, 0xef, 0x94, 0x58, 0x92, 0x9c, 0xe1, 0xe9, 0x52, 0x1c, 0x94, 0x5a, 0x58, 0x9a,
-	0x5a, 0x5c, 0x22, 0x24, 0xc6, 0xc5, 0x96, 0x93, 0x9a, 0x97, 0x5e, 0x92, 0x01, 0xd6, 0xc8, 0x1a,
-	0x04, 0xe5, 0x19, 0x2d, 0x60, 0xe4, 0x12, 0x80, 0x1b, 0x1d, 0x9c, 0x5a, 0x54, 0x96, 0x99, 0x9c,
-	0x2a, 0x64, 0xcb, 0xc5, 0xe6, 0x97, 0x5a, 0x51, 0xe2, 0xe9, 0x22, 0x24, 0xa6, 0x07, 0xf1, 0x85,
-	0x1e, 0xcc, 0x7d, 0x7a, 0xae, 0x20, 0x5f, 0x48, 0x49, 0xea, 0xa1, 0xfa, 0x56, 0x0f, 0x6e, 0x86,
-	0x12, 0x83, 0x90, 0x2f, 0x17, 0x37, 0xd8, 0x7a, 0xa8, 0x19, 0xf2, 0xe8, 0x6a, 0xd1, 0xdc, 0x86,
-	0xd7, 0x30, 0x03, 0x46, 0x27, 0x91, 0x13, 0x8f, 0xe4, 0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0,
-	0x48, 0x8e, 0xf1, 0xc0, 0x63, 0x39, 0xc6, 0x28, 0xa6, 0x32, 0xc3, 0x24, 0x36, 0xb0, 0x8b, 0x8c,
-	0x01, 0x01, 0x00, 0x00, 0xff, 0xff, 0x0e, 0xdf, 0x27, 0xe6, 0x86, 0x01, 0x00, 0x00,
+	TimeBits       uint32 `protobuf:"varint,1,opt,name=time_bits,json=timeBits,proto3" json:"time_bits,omitempty"`
+	DatacenterBits uint32 `protobuf:"varint,2,opt,name=datacenter_bits,json=datacenterBits,proto3" json:"datacenter_bits,omitempty"`
+	MachineBits    uint32 `protobuf:"varint,3,opt,name=machine_bits,json=machineBits,proto3" json:"machine_bits,omitempty"`
+	SequenceBits   uint32 `protobuf:"varint,4,opt,name=sequence_bits,json=sequenceBits,proto3" json:"sequence_bits,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (x *IDLayout) Reset() {
+	*x = IDLayout{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_snowman_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-// SnowflakeServiceClient is the client API for SnowflakeService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type SnowflakeServiceClient interface {
-	NextID(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*Snowflake, error)
-	BatchNextID(ctx context.Context, in *BatchIDsRequest, opts ...grpc.CallOption) (SnowflakeService_BatchNextIDClient, error)
+func (x *IDLayout) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type snowflakeServiceClient struct {
-	cc *grpc.ClientConn
+func (*IDLayout) ProtoMessage() {}
+
+func (x *IDLayout) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_snowman_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func NewSnowflakeServiceClient(cc *grpc.ClientConn) SnowflakeServiceClient {
-	return &snowflakeServiceClient{cc}
+// Deprecated: Use IDLayout.ProtoReflect.Descriptor instead.
+func (*IDLayout) Descriptor() ([]byte, []int) {
+	return file_v1_snowman_proto_rawDescGZIP(), []int{2}
 }
 
-func (c *snowflakeServiceClient) NextID(ctx context.Context, in *types.Empty, opts ...grpc.CallOption) (*Snowflake, error) {
-	out := new(Snowflake)
-	err := c.cc.Invoke(ctx, "/snowman.api.v1.SnowflakeService/NextID", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (x *IDLayout) GetTimeBits() uint32 {
+	if x != nil {
+		return x.TimeBits
 	}
-	return out, nil
+	return 0
 }
 
-func (c *snowflakeServiceClient) BatchNextID(ctx context.Context, in *BatchIDsRequest, opts ...grpc.CallOption) (SnowflakeService_BatchNextIDClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_SnowflakeService_serviceDesc.Streams[0], "/snowman.api.v1.SnowflakeService/BatchNextID", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &snowflakeServiceBatchNextIDClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+func (x *IDLayout) GetDatacenterBits() uint32 {
+	if x != nil {
+		return x.DatacenterBits
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+	return 0
 }
 
-type SnowflakeService_BatchNextIDClient interface {
-	Recv() (*Snowflake, error)
-	grpc.ClientStream
+func (x *IDLayout) GetMachineBits() uint32 {
+	if x != nil {
+		return x.MachineBits
+	}
+	return 0
 }
 
-type snowflakeServiceBatchNextIDClient struct {
-	grpc.ClientStream
+func (x *IDLayout) GetSequenceBits() uint32 {
+	if x != nil {
+		return x.SequenceBits
+	}
+	return 0
 }
 
-func (x *snowflakeServiceBatchNextIDClient) Recv() (*Snowflake, error) {
-	m := new(Snowflake)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+type ServerInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Layout *IDLayout              `protobuf:"bytes,1,opt,name=layout,proto3" json:"layout,omitempty"`
+	Epoch  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// machine_id spans both the datacenter and the machine fields.
+	MachineId int32  `protobuf:"varint,3,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	Version   string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// logical_time is the later of the wall clock and the time of the last
+	// issued ID.
+	LogicalTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=logical_time,json=logicalTime,proto3" json:"logical_time,omitempty"`
+	// sequence_remaining is how many IDs are left in the current millisecond.
+	SequenceRemaining int64 `protobuf:"varint,6,opt,name=sequence_remaining,json=sequenceRemaining,proto3" json:"sequence_remaining,omitempty"`
+	// lease_expires is set when the machine ID is leased.
+	LeaseExpires *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=lease_expires,json=leaseExpires,proto3" json:"lease_expires,omitempty"`
+}
+
+func (x *ServerInfo) Reset() {
+	*x = ServerInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_snowman_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return m, nil
 }
 
-// SnowflakeServiceServer is the server API for SnowflakeService service.
-type SnowflakeServiceServer interface {
-	NextID(context.Context, *types.Empty) (*Snowflake, error)
-	BatchNextID(*BatchIDsRequest, SnowflakeService_BatchNextIDServer) error
+func (x *ServerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-// UnimplementedSnowflakeServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedSnowflakeServiceServer struct {
-}
+func (*ServerInfo) ProtoMessage() {}
 
-func (*UnimplementedSnowflakeServiceServer) NextID(ctx context.Context, req *types.Empty) (*Snowflake, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method NextID not implemented")
+func (x *ServerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_snowman_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (*UnimplementedSnowflakeServiceServer) BatchNextID(req *BatchIDsRequest, srv SnowflakeService_BatchNextIDServer) error {
-	return status.Errorf(codes.Unimplemented, "method BatchNextID not implemented")
+
+// Deprecated: Use ServerInfo.ProtoReflect.Descriptor instead.
+func (*ServerInfo) Descriptor() ([]byte, []int) {
+	return file_v1_snowman_proto_rawDescGZIP(), []int{3}
 }
 
-func RegisterSnowflakeServiceServer(s *grpc.Server, srv SnowflakeServiceServer) {
-	s.RegisterService(&_SnowflakeService_serviceDesc, srv)
+func (x *ServerInfo) GetLayout() *IDLayout {
+	if x != nil {
+		return x.Layout
+	}
+	return nil
 }
 
-func _SnowflakeService_NextID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(types.Empty)
-	if err := dec(in); err != nil {
-		return nil, err
+func (x *ServerInfo) GetEpoch() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Epoch
 	}
-	if interceptor == nil {
-		return srv.(SnowflakeServiceServer).NextID(ctx, in)
+	return nil
+}
+
+func (x *ServerInfo) GetMachineId() int32 {
+	if x != nil {
+		return x.MachineId
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/snowman.api.v1.SnowflakeService/NextID",
+	return 0
+}
+
+func (x *ServerInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SnowflakeServiceServer).NextID(ctx, req.(*types.Empty))
+	return ""
+}
+
+func (x *ServerInfo) GetLogicalTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LogicalTime
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _SnowflakeService_BatchNextID_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(BatchIDsRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (x *ServerInfo) GetSequenceRemaining() int64 {
+	if x != nil {
+		return x.SequenceRemaining
 	}
-	return srv.(SnowflakeServiceServer).BatchNextID(m, &snowflakeServiceBatchNextIDServer{stream})
+	return 0
 }
 
-type SnowflakeService_BatchNextIDServer interface {
-	Send(*Snowflake) error
-	grpc.ServerStream
+func (x *ServerInfo) GetLeaseExpires() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LeaseExpires
+	}
+	return nil
 }
 
-type snowflakeServiceBatchNextIDServer struct {
-	grpc.ServerStream
+type SnowflakeRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// start is the first ID of the range, the others follow it. It is
+	// encoded like Snowflake.id.
+	Start []byte `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Count int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
 }
 
-func (x *snowflakeServiceBatchNextIDServer) Send(m *Snowflake) error {
-	return x.ServerStream.SendMsg(m)
+func (x *SnowflakeRange) Reset() {
+	*x = SnowflakeRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_snowman_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var _SnowflakeService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "snowman.api.v1.SnowflakeService",
-	HandlerType: (*SnowflakeServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "NextID",
-			Handler:    _SnowflakeService_NextID_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "BatchNextID",
-			Handler:       _SnowflakeService_BatchNextID_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "snowman.proto",
+func (x *SnowflakeRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *Snowflake) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (*SnowflakeRange) ProtoMessage() {}
+
+func (x *SnowflakeRange) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_snowman_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return dAtA[:n], nil
+	return mi.MessageOf(x)
 }
 
-func (m *Snowflake) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Deprecated: Use SnowflakeRange.ProtoReflect.Descriptor instead.
+func (*SnowflakeRange) Descriptor() ([]byte, []int) {
+	return file_v1_snowman_proto_rawDescGZIP(), []int{4}
 }
 
-func (m *Snowflake) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (x *SnowflakeRange) GetStart() []byte {
+	if x != nil {
+		return x.Start
 	}
-	{
-		size := m.ID.Size()
-		i -= size
-		if _, err := m.ID.MarshalTo(dAtA[i:]); err != nil {
-			return 0, err
-		}
-		i = encodeVarintSnowman(dAtA, i, uint64(size))
-	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *BatchIDsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (x *SnowflakeRange) GetCount() int32 {
+	if x != nil {
+		return x.Count
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *BatchIDsRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SnowflakeRanges struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ranges []*SnowflakeRange `protobuf:"bytes,1,rep,name=ranges,proto3" json:"ranges,omitempty"`
 }
 
-func (m *BatchIDsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (x *SnowflakeRanges) Reset() {
+	*x = SnowflakeRanges{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_snowman_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if m.Length != 0 {
-		i = encodeVarintSnowman(dAtA, i, uint64(m.Length))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
 }
 
-func encodeVarintSnowman(dAtA []byte, offset int, v uint64) int {
-	offset -= sovSnowman(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
+func (x *SnowflakeRanges) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *Snowflake) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.ID.Size()
-	n += 1 + l + sovSnowman(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+
+func (*SnowflakeRanges) ProtoMessage() {}
+
+func (x *SnowflakeRanges) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_snowman_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return n
+	return mi.MessageOf(x)
 }
 
-func (m *BatchIDsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Length != 0 {
-		n += 1 + sovSnowman(uint64(m.Length))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+// Deprecated: Use SnowflakeRanges.ProtoReflect.Descriptor instead.
+func (*SnowflakeRanges) Descriptor() ([]byte, []int) {
+	return file_v1_snowman_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SnowflakeRanges) GetRanges() []*SnowflakeRange {
+	if x != nil {
+		return x.Ranges
 	}
-	return n
+	return nil
 }
 
-func sovSnowman(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+type SnowflakeList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []uint64 `protobuf:"fixed64,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
 }
-func sozSnowman(x uint64) (n int) {
-	return sovSnowman(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (x *SnowflakeList) Reset() {
+	*x = SnowflakeList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_snowman_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *Snowflake) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowSnowman
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Snowflake: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Snowflake: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSnowman
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthSnowman
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthSnowman
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipSnowman(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthSnowman
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthSnowman
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
+
+func (x *SnowflakeList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnowflakeList) ProtoMessage() {}
+
+func (x *SnowflakeList) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_snowman_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
+	return mi.MessageOf(x)
+}
 
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
+// Deprecated: Use SnowflakeList.ProtoReflect.Descriptor instead.
+func (*SnowflakeList) Descriptor() ([]byte, []int) {
+	return file_v1_snowman_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SnowflakeList) GetIds() []uint64 {
+	if x != nil {
+		return x.Ids
 	}
 	return nil
 }
-func (m *BatchIDsRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowSnowman
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: BatchIDsRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BatchIDsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Length", wireType)
-			}
-			m.Length = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSnowman
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Length |= int32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipSnowman(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthSnowman
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthSnowman
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
+
+type BatchIDsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Length int32 `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
+}
+
+func (x *BatchIDsRequest) Reset() {
+	*x = BatchIDsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_snowman_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchIDsRequest) ProtoMessage() {}
+
+func (x *BatchIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_snowman_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
+	return mi.MessageOf(x)
+}
 
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
+// Deprecated: Use BatchIDsRequest.ProtoReflect.Descriptor instead.
+func (*BatchIDsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_snowman_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchIDsRequest) GetLength() int32 {
+	if x != nil {
+		return x.Length
 	}
-	return nil
+	return 0
 }
-func skipSnowman(dAtA []byte) (n int, err error) {
-	l := len(dAtA)
-	iNdEx := 0
-	depth := 0
-	for iNdEx < l {
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return 0, ErrIntOverflowSnowman
-			}
-			if iNdEx >= l {
-				return 0, io.ErrUnexpectedEOF
+
+var File_v1_snowman_proto protoreflect.FileDescriptor
+
+var file_v1_snowman_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x76, 0x31, 0x2f, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0e, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61,
+	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x1b,
+	0x0a, 0x09, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x22, 0xa0, 0x01, 0x0a, 0x0e,
+	0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x50, 0x61, 0x72, 0x74, 0x73, 0x12, 0x2e,
+	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x63, 0x65, 0x6e, 0x74, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x98,
+	0x01, 0x0a, 0x08, 0x49, 0x44, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x62, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x74, 0x69, 0x6d, 0x65, 0x42, 0x69, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x61, 0x74, 0x61,
+	0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x62, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x42, 0x69, 0x74,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x62, 0x69, 0x74,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x42, 0x69, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x5f, 0x62, 0x69, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x69, 0x74, 0x73, 0x22, 0xd8, 0x02, 0x0a, 0x0a, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x30, 0x0a, 0x06, 0x6c, 0x61, 0x79, 0x6f,
+	0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x6e, 0x6f, 0x77, 0x6d,
+	0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x44, 0x4c, 0x61, 0x79, 0x6f,
+	0x75, 0x74, 0x52, 0x06, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x30, 0x0a, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x5f, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x11, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e,
+	0x69, 0x6e, 0x67, 0x12, 0x3f, 0x0a, 0x0d, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x22, 0x3c, 0x0a, 0x0e, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b,
+	0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x49, 0x0a, 0x0f, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x21, 0x0a,
+	0x0d, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x06, 0x52, 0x03, 0x69, 0x64, 0x73,
+	0x22, 0x29, 0x0a, 0x0f, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x32, 0xb1, 0x04, 0x0a, 0x10,
+	0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x4b, 0x0a, 0x06, 0x4e, 0x65, 0x78, 0x74, 0x49, 0x44, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x19, 0x2e, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x22, 0x0e, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x08, 0x12, 0x06, 0x2f, 0x76, 0x31, 0x2f, 0x69, 0x64, 0x12, 0x5c, 0x0a,
+	0x0b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4e, 0x65, 0x78, 0x74, 0x49, 0x44, 0x12, 0x1f, 0x2e, 0x73,
+	0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x22, 0x0f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x09,
+	0x12, 0x07, 0x2f, 0x76, 0x31, 0x2f, 0x69, 0x64, 0x73, 0x30, 0x01, 0x12, 0x5f, 0x0a, 0x07, 0x4e,
+	0x65, 0x78, 0x74, 0x49, 0x44, 0x73, 0x12, 0x1f, 0x2e, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x44, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61,
+	0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61,
+	0x6b, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x12, 0x0c,
+	0x2f, 0x76, 0x31, 0x2f, 0x69, 0x64, 0x73, 0x3a, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x64, 0x0a, 0x0c,
+	0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1f, 0x2e, 0x73,
+	0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x12,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x12, 0x5a, 0x0a, 0x06, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x19, 0x2e, 0x73,
+	0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e,
+	0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x1a, 0x1e, 0x2e, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61,
+	0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61,
+	0x6b, 0x65, 0x50, 0x61, 0x72, 0x74, 0x73, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x3a,
+	0x01, 0x2a, 0x22, 0x0a, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x4f,
+	0x0a, 0x07, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x1a, 0x2e, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x10, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x0a, 0x12, 0x08, 0x2f, 0x76, 0x31, 0x2f, 0x69, 0x6e, 0x66, 0x6f, 0x42,
+	0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x68,
+	0x61, 0x74, 0x69, 0x71, 0x75, 0x65, 0x2f, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_v1_snowman_proto_rawDescOnce sync.Once
+	file_v1_snowman_proto_rawDescData = file_v1_snowman_proto_rawDesc
+)
+
+func file_v1_snowman_proto_rawDescGZIP() []byte {
+	file_v1_snowman_proto_rawDescOnce.Do(func() {
+		file_v1_snowman_proto_rawDescData = protoimpl.X.CompressGZIP(file_v1_snowman_proto_rawDescData)
+	})
+	return file_v1_snowman_proto_rawDescData
+}
+
+var file_v1_snowman_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_v1_snowman_proto_goTypes = []any{
+	(*Snowflake)(nil),             // 0: snowman.api.v1.Snowflake
+	(*SnowflakeParts)(nil),        // 1: snowman.api.v1.SnowflakeParts
+	(*IDLayout)(nil),              // 2: snowman.api.v1.IDLayout
+	(*ServerInfo)(nil),            // 3: snowman.api.v1.ServerInfo
+	(*SnowflakeRange)(nil),        // 4: snowman.api.v1.SnowflakeRange
+	(*SnowflakeRanges)(nil),       // 5: snowman.api.v1.SnowflakeRanges
+	(*SnowflakeList)(nil),         // 6: snowman.api.v1.SnowflakeList
+	(*BatchIDsRequest)(nil),       // 7: snowman.api.v1.BatchIDsRequest
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 9: google.protobuf.Empty
+}
+var file_v1_snowman_proto_depIdxs = []int32{
+	8,  // 0: snowman.api.v1.SnowflakeParts.time:type_name -> google.protobuf.Timestamp
+	2,  // 1: snowman.api.v1.ServerInfo.layout:type_name -> snowman.api.v1.IDLayout
+	8,  // 2: snowman.api.v1.ServerInfo.epoch:type_name -> google.protobuf.Timestamp
+	8,  // 3: snowman.api.v1.ServerInfo.logical_time:type_name -> google.protobuf.Timestamp
+	8,  // 4: snowman.api.v1.ServerInfo.lease_expires:type_name -> google.protobuf.Timestamp
+	4,  // 5: snowman.api.v1.SnowflakeRanges.ranges:type_name -> snowman.api.v1.SnowflakeRange
+	9,  // 6: snowman.api.v1.SnowflakeService.NextID:input_type -> google.protobuf.Empty
+	7,  // 7: snowman.api.v1.SnowflakeService.BatchNextID:input_type -> snowman.api.v1.BatchIDsRequest
+	7,  // 8: snowman.api.v1.SnowflakeService.NextIDs:input_type -> snowman.api.v1.BatchIDsRequest
+	7,  // 9: snowman.api.v1.SnowflakeService.ReserveRange:input_type -> snowman.api.v1.BatchIDsRequest
+	0,  // 10: snowman.api.v1.SnowflakeService.Decode:input_type -> snowman.api.v1.Snowflake
+	9,  // 11: snowman.api.v1.SnowflakeService.GetInfo:input_type -> google.protobuf.Empty
+	0,  // 12: snowman.api.v1.SnowflakeService.NextID:output_type -> snowman.api.v1.Snowflake
+	0,  // 13: snowman.api.v1.SnowflakeService.BatchNextID:output_type -> snowman.api.v1.Snowflake
+	6,  // 14: snowman.api.v1.SnowflakeService.NextIDs:output_type -> snowman.api.v1.SnowflakeList
+	5,  // 15: snowman.api.v1.SnowflakeService.ReserveRange:output_type -> snowman.api.v1.SnowflakeRanges
+	1,  // 16: snowman.api.v1.SnowflakeService.Decode:output_type -> snowman.api.v1.SnowflakeParts
+	3,  // 17: snowman.api.v1.SnowflakeService.GetInfo:output_type -> snowman.api.v1.ServerInfo
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_v1_snowman_proto_init() }
+func file_v1_snowman_proto_init() {
+	if File_v1_snowman_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_v1_snowman_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Snowflake); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+		}
+		file_v1_snowman_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*SnowflakeParts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
 			}
 		}
-		wireType := int(wire & 0x7)
-		switch wireType {
-		case 0:
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return 0, ErrIntOverflowSnowman
-				}
-				if iNdEx >= l {
-					return 0, io.ErrUnexpectedEOF
-				}
-				iNdEx++
-				if dAtA[iNdEx-1] < 0x80 {
-					break
-				}
+		file_v1_snowman_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*IDLayout); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
 			}
-		case 1:
-			iNdEx += 8
-		case 2:
-			var length int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return 0, ErrIntOverflowSnowman
-				}
-				if iNdEx >= l {
-					return 0, io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				length |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		}
+		file_v1_snowman_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ServerInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
 			}
-			if length < 0 {
-				return 0, ErrInvalidLengthSnowman
+		}
+		file_v1_snowman_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*SnowflakeRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
 			}
-			iNdEx += length
-		case 3:
-			depth++
-		case 4:
-			if depth == 0 {
-				return 0, ErrUnexpectedEndOfGroupSnowman
+		}
+		file_v1_snowman_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*SnowflakeRanges); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
 			}
-			depth--
-		case 5:
-			iNdEx += 4
-		default:
-			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
-		if iNdEx < 0 {
-			return 0, ErrInvalidLengthSnowman
+		file_v1_snowman_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*SnowflakeList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
-		if depth == 0 {
-			return iNdEx, nil
+		file_v1_snowman_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*BatchIDsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
 	}
-	return 0, io.ErrUnexpectedEOF
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_v1_snowman_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_v1_snowman_proto_goTypes,
+		DependencyIndexes: file_v1_snowman_proto_depIdxs,
+		MessageInfos:      file_v1_snowman_proto_msgTypes,
+	}.Build()
+	File_v1_snowman_proto = out.File
+	file_v1_snowman_proto_rawDesc = nil
+	file_v1_snowman_proto_goTypes = nil
+	file_v1_snowman_proto_depIdxs = nil
 }
-
-var (
-	ErrInvalidLengthSnowman        = fmt.Errorf("proto: negative length found during unmarshaling")
-	ErrIntOverflowSnowman          = fmt.Errorf("proto: integer overflow")
-	ErrUnexpectedEndOfGroupSnowman = fmt.Errorf("proto: unexpected end of group")
-)
@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// IDs are stored in SQL databases as a signed 64 bit integer, such as a
+// BIGINT column. That holds IDs below 2^63, which is all the IDs of a
+// layout filling 63 bits, like TwitterLayout. Layouts filling 64 bits only
+// set the top bit once the time field has run through half its range, that
+// is about 70 years after the epoch for DefaultLayout. ID.Value refuses the
+// IDs that don't fit rather than store them as negative numbers.
+
+var (
+	_ sql.Scanner   = (*ID)(nil)
+	_ driver.Valuer = ID(0)
+	_ sql.Scanner   = (*NullID)(nil)
+	_ driver.Valuer = NullID{}
+)
+
+// Value implements driver.Valuer. It fails for IDs at or above 2^63, which
+// don't fit a signed 64 bit integer.
+func (id ID) Value() (driver.Value, error) {
+	if uint64(id) > math.MaxInt64 {
+		return nil, fmt.Errorf("ID %s doesn't fit a signed 64 bit integer", id)
+	}
+	return int64(id), nil
+}
+
+// Scan implements sql.Scanner. It reads non negative integers, and decimal
+// strings, which is how some drivers return unsigned 64 bit columns.
+func (id *ID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("can't scan negative integer %d into an ID", v)
+		}
+		*id = ID(v)
+	case []byte:
+		return id.scanDecimal(string(v))
+	case string:
+		return id.scanDecimal(v)
+	case nil:
+		return fmt.Errorf("can't scan NULL into an ID, use NullID")
+	default:
+		return fmt.Errorf("can't scan %T into an ID", src)
+	}
+	return nil
+}
+
+func (id *ID) scanDecimal(s string) error {
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("can't scan %q into an ID: %v", s, err)
+	}
+	*id = ID(v)
+	return nil
+}
+
+// NullID is an ID that may be NULL in a database. It implements sql.Scanner
+// and driver.Valuer like sql.NullInt64.
+type NullID struct {
+	ID ID
+	// Valid is true if ID is not NULL.
+	Valid bool
+}
+
+// Scan implements sql.Scanner.
+func (n *NullID) Scan(src interface{}) error {
+	if src == nil {
+		n.ID, n.Valid = ID(0), false
+		return nil
+	}
+	err := n.ID.Scan(src)
+	n.Valid = err == nil
+	return err
+}
+
+// Value implements driver.Valuer.
+func (n NullID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.ID.Value()
+}
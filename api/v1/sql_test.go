@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"database/sql/driver"
+	"math"
+	"testing"
+)
+
+func TestIDValue(t *testing.T) {
+	for _, test := range []struct {
+		id      ID
+		want    driver.Value
+		wantErr bool
+	}{
+		{id: 0, want: int64(0)},
+		{id: 42, want: int64(42)},
+		{id: math.MaxInt64, want: int64(math.MaxInt64)},
+		{id: math.MaxInt64 + 1, wantErr: true},
+		{id: math.MaxUint64, wantErr: true},
+	} {
+		got, err := test.id.Value()
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("ID(%d).Value() = %v, want an error", uint64(test.id), got)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("ID(%d).Value() = %v, %v; want %v", uint64(test.id), got, err, test.want)
+		}
+	}
+}
+
+func TestIDScan(t *testing.T) {
+	for _, test := range []struct {
+		src     interface{}
+		want    ID
+		wantErr bool
+	}{
+		{src: int64(0), want: 0},
+		{src: int64(math.MaxInt64), want: math.MaxInt64},
+		{src: int64(-1), wantErr: true},
+		{src: []byte("18446744073709551615"), want: math.MaxUint64},
+		{src: "9223372036854775808", want: math.MaxInt64 + 1},
+		{src: "42", want: 42},
+		{src: "-42", wantErr: true},
+		{src: []byte("2a"), wantErr: true},
+		{src: "18446744073709551616", wantErr: true},
+		{src: 4.2, wantErr: true},
+		{src: nil, wantErr: true},
+	} {
+		var id ID
+		err := id.Scan(test.src)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("Scan(%#v) = %d, want an error", test.src, uint64(id))
+			}
+			continue
+		}
+		if err != nil || id != test.want {
+			t.Errorf("Scan(%#v) = %d, %v; want %d", test.src, uint64(id), err, uint64(test.want))
+		}
+	}
+}
+
+func TestNullID(t *testing.T) {
+	n := NullID{ID: 42, Valid: true}
+	if err := n.Scan(nil); err != nil || n.Valid || n.ID != 0 {
+		t.Errorf("NullID.Scan(nil) = %+v, %v; want an invalid NullID", n, err)
+	}
+	if v, err := n.Value(); v != nil || err != nil {
+		t.Errorf("Value of a NULL NullID = %v, %v; want nil", v, err)
+	}
+
+	if err := n.Scan("42"); err != nil || !n.Valid || n.ID != 42 {
+		t.Errorf("NullID.Scan(\"42\") = %+v, %v; want a valid 42", n, err)
+	}
+	if v, err := n.Value(); v != int64(42) || err != nil {
+		t.Errorf("Value of a valid NullID = %v, %v; want 42", v, err)
+	}
+
+	if err := n.Scan(int64(-1)); err == nil || n.Valid {
+		t.Errorf("NullID.Scan(-1) = %+v, %v; want an error", n, err)
+	}
+	if _, err := (NullID{ID: math.MaxUint64, Valid: true}).Value(); err == nil {
+		t.Error("Value of a NullID above MaxInt64 succeeded, want an error")
+	}
+}
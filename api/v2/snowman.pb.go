@@ -0,0 +1,797 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: v2/snowman.proto
+
+package v2
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Snowflake carries an ID as a plain unsigned 64 bit number, so non-Go
+// clients read it as is. In JSON it is a decimal string.
+type Snowflake struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"fixed64,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *Snowflake) Reset() {
+	*x = Snowflake{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_snowman_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snowflake) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snowflake) ProtoMessage() {}
+
+func (x *Snowflake) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_snowman_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snowflake.ProtoReflect.Descriptor instead.
+func (*Snowflake) Descriptor() ([]byte, []int) {
+	return file_v2_snowman_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Snowflake) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type SnowflakeParts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// time is when the ID was created, with millisecond precision.
+	Time *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// datacenter_id is zero for layouts without a datacenter field.
+	DatacenterId int32 `protobuf:"varint,2,opt,name=datacenter_id,json=datacenterId,proto3" json:"datacenter_id,omitempty"`
+	// machine_id is the machine within the datacenter.
+	MachineId int32 `protobuf:"varint,3,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	Sequence  int32 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *SnowflakeParts) Reset() {
+	*x = SnowflakeParts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_snowman_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnowflakeParts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnowflakeParts) ProtoMessage() {}
+
+func (x *SnowflakeParts) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_snowman_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnowflakeParts.ProtoReflect.Descriptor instead.
+func (*SnowflakeParts) Descriptor() ([]byte, []int) {
+	return file_v2_snowman_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SnowflakeParts) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *SnowflakeParts) GetDatacenterId() int32 {
+	if x != nil {
+		return x.DatacenterId
+	}
+	return 0
+}
+
+func (x *SnowflakeParts) GetMachineId() int32 {
+	if x != nil {
+		return x.MachineId
+	}
+	return 0
+}
+
+func (x *SnowflakeParts) GetSequence() int32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type IDLayout struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TimeBits       uint32 `protobuf:"varint,1,opt,name=time_bits,json=timeBits,proto3" json:"time_bits,omitempty"`
+	DatacenterBits uint32 `protobuf:"varint,2,opt,name=datacenter_bits,json=datacenterBits,proto3" json:"datacenter_bits,omitempty"`
+	MachineBits    uint32 `protobuf:"varint,3,opt,name=machine_bits,json=machineBits,proto3" json:"machine_bits,omitempty"`
+	SequenceBits   uint32 `protobuf:"varint,4,opt,name=sequence_bits,json=sequenceBits,proto3" json:"sequence_bits,omitempty"`
+}
+
+func (x *IDLayout) Reset() {
+	*x = IDLayout{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_snowman_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IDLayout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IDLayout) ProtoMessage() {}
+
+func (x *IDLayout) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_snowman_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IDLayout.ProtoReflect.Descriptor instead.
+func (*IDLayout) Descriptor() ([]byte, []int) {
+	return file_v2_snowman_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IDLayout) GetTimeBits() uint32 {
+	if x != nil {
+		return x.TimeBits
+	}
+	return 0
+}
+
+func (x *IDLayout) GetDatacenterBits() uint32 {
+	if x != nil {
+		return x.DatacenterBits
+	}
+	return 0
+}
+
+func (x *IDLayout) GetMachineBits() uint32 {
+	if x != nil {
+		return x.MachineBits
+	}
+	return 0
+}
+
+func (x *IDLayout) GetSequenceBits() uint32 {
+	if x != nil {
+		return x.SequenceBits
+	}
+	return 0
+}
+
+type ServerInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Layout *IDLayout              `protobuf:"bytes,1,opt,name=layout,proto3" json:"layout,omitempty"`
+	Epoch  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// machine_id spans both the datacenter and the machine fields.
+	MachineId int32  `protobuf:"varint,3,opt,name=machine_id,json=machineId,proto3" json:"machine_id,omitempty"`
+	Version   string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// logical_time is the later of the wall clock and the time of the last
+	// issued ID.
+	LogicalTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=logical_time,json=logicalTime,proto3" json:"logical_time,omitempty"`
+	// sequence_remaining is how many IDs are left in the current millisecond.
+	SequenceRemaining int64 `protobuf:"varint,6,opt,name=sequence_remaining,json=sequenceRemaining,proto3" json:"sequence_remaining,omitempty"`
+	// lease_expires is set when the machine ID is leased.
+	LeaseExpires *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=lease_expires,json=leaseExpires,proto3" json:"lease_expires,omitempty"`
+}
+
+func (x *ServerInfo) Reset() {
+	*x = ServerInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_snowman_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerInfo) ProtoMessage() {}
+
+func (x *ServerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_snowman_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerInfo.ProtoReflect.Descriptor instead.
+func (*ServerInfo) Descriptor() ([]byte, []int) {
+	return file_v2_snowman_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ServerInfo) GetLayout() *IDLayout {
+	if x != nil {
+		return x.Layout
+	}
+	return nil
+}
+
+func (x *ServerInfo) GetEpoch() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Epoch
+	}
+	return nil
+}
+
+func (x *ServerInfo) GetMachineId() int32 {
+	if x != nil {
+		return x.MachineId
+	}
+	return 0
+}
+
+func (x *ServerInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ServerInfo) GetLogicalTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LogicalTime
+	}
+	return nil
+}
+
+func (x *ServerInfo) GetSequenceRemaining() int64 {
+	if x != nil {
+		return x.SequenceRemaining
+	}
+	return 0
+}
+
+func (x *ServerInfo) GetLeaseExpires() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LeaseExpires
+	}
+	return nil
+}
+
+type SnowflakeRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// start is the first ID of the range, the others follow it.
+	Start uint64 `protobuf:"fixed64,1,opt,name=start,proto3" json:"start,omitempty"`
+	Count int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *SnowflakeRange) Reset() {
+	*x = SnowflakeRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_snowman_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnowflakeRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnowflakeRange) ProtoMessage() {}
+
+func (x *SnowflakeRange) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_snowman_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnowflakeRange.ProtoReflect.Descriptor instead.
+func (*SnowflakeRange) Descriptor() ([]byte, []int) {
+	return file_v2_snowman_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SnowflakeRange) GetStart() uint64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *SnowflakeRange) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type SnowflakeRanges struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ranges []*SnowflakeRange `protobuf:"bytes,1,rep,name=ranges,proto3" json:"ranges,omitempty"`
+}
+
+func (x *SnowflakeRanges) Reset() {
+	*x = SnowflakeRanges{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_snowman_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnowflakeRanges) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnowflakeRanges) ProtoMessage() {}
+
+func (x *SnowflakeRanges) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_snowman_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnowflakeRanges.ProtoReflect.Descriptor instead.
+func (*SnowflakeRanges) Descriptor() ([]byte, []int) {
+	return file_v2_snowman_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SnowflakeRanges) GetRanges() []*SnowflakeRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+type SnowflakeList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []uint64 `protobuf:"fixed64,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *SnowflakeList) Reset() {
+	*x = SnowflakeList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_snowman_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnowflakeList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnowflakeList) ProtoMessage() {}
+
+func (x *SnowflakeList) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_snowman_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnowflakeList.ProtoReflect.Descriptor instead.
+func (*SnowflakeList) Descriptor() ([]byte, []int) {
+	return file_v2_snowman_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SnowflakeList) GetIds() []uint64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type BatchIDsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Length int32 `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
+}
+
+func (x *BatchIDsRequest) Reset() {
+	*x = BatchIDsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v2_snowman_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchIDsRequest) ProtoMessage() {}
+
+func (x *BatchIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_snowman_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchIDsRequest.ProtoReflect.Descriptor instead.
+func (*BatchIDsRequest) Descriptor() ([]byte, []int) {
+	return file_v2_snowman_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchIDsRequest) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+var File_v2_snowman_proto protoreflect.FileDescriptor
+
+var file_v2_snowman_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x76, 0x32, 0x2f, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0e, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x32, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61,
+	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x1b,
+	0x0a, 0x09, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x06, 0x52, 0x02, 0x69, 0x64, 0x22, 0xa0, 0x01, 0x0a, 0x0e,
+	0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x50, 0x61, 0x72, 0x74, 0x73, 0x12, 0x2e,
+	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x63, 0x65, 0x6e, 0x74, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x98,
+	0x01, 0x0a, 0x08, 0x49, 0x44, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x62, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x74, 0x69, 0x6d, 0x65, 0x42, 0x69, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x61, 0x74, 0x61,
+	0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x62, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0e, 0x64, 0x61, 0x74, 0x61, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x42, 0x69, 0x74,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x62, 0x69, 0x74,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x42, 0x69, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x5f, 0x62, 0x69, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x69, 0x74, 0x73, 0x22, 0xd8, 0x02, 0x0a, 0x0a, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x30, 0x0a, 0x06, 0x6c, 0x61, 0x79, 0x6f,
+	0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x6e, 0x6f, 0x77, 0x6d,
+	0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x49, 0x44, 0x4c, 0x61, 0x79, 0x6f,
+	0x75, 0x74, 0x52, 0x06, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x30, 0x0a, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x5f, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x11, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e,
+	0x69, 0x6e, 0x67, 0x12, 0x3f, 0x0a, 0x0d, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x22, 0x3c, 0x0a, 0x0e, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b,
+	0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x06, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x49, 0x0a, 0x0f, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x21, 0x0a,
+	0x0d, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x06, 0x52, 0x03, 0x69, 0x64, 0x73,
+	0x22, 0x29, 0x0a, 0x0f, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x32, 0xb1, 0x04, 0x0a, 0x10,
+	0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x4b, 0x0a, 0x06, 0x4e, 0x65, 0x78, 0x74, 0x49, 0x44, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x19, 0x2e, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x32, 0x2e, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x22, 0x0e, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x08, 0x12, 0x06, 0x2f, 0x76, 0x32, 0x2f, 0x69, 0x64, 0x12, 0x5c, 0x0a,
+	0x0b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4e, 0x65, 0x78, 0x74, 0x49, 0x44, 0x12, 0x1f, 0x2e, 0x73,
+	0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x53,
+	0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x22, 0x0f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x09,
+	0x12, 0x07, 0x2f, 0x76, 0x32, 0x2f, 0x69, 0x64, 0x73, 0x30, 0x01, 0x12, 0x5f, 0x0a, 0x07, 0x4e,
+	0x65, 0x78, 0x74, 0x49, 0x44, 0x73, 0x12, 0x1f, 0x2e, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x44, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61,
+	0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61,
+	0x6b, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x12, 0x0c,
+	0x2f, 0x76, 0x32, 0x2f, 0x69, 0x64, 0x73, 0x3a, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x64, 0x0a, 0x0c,
+	0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1f, 0x2e, 0x73,
+	0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x53,
+	0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x12,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x76, 0x32, 0x2f, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x12, 0x5a, 0x0a, 0x06, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x19, 0x2e, 0x73,
+	0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x6e,
+	0x6f, 0x77, 0x66, 0x6c, 0x61, 0x6b, 0x65, 0x1a, 0x1e, 0x2e, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61,
+	0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x6e, 0x6f, 0x77, 0x66, 0x6c, 0x61,
+	0x6b, 0x65, 0x50, 0x61, 0x72, 0x74, 0x73, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x3a,
+	0x01, 0x2a, 0x22, 0x0a, 0x2f, 0x76, 0x32, 0x2f, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x4f,
+	0x0a, 0x07, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x1a, 0x2e, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x32, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x10, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x0a, 0x12, 0x08, 0x2f, 0x76, 0x32, 0x2f, 0x69, 0x6e, 0x66, 0x6f, 0x42,
+	0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x68,
+	0x61, 0x74, 0x69, 0x71, 0x75, 0x65, 0x2f, 0x73, 0x6e, 0x6f, 0x77, 0x6d, 0x61, 0x6e, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x32, 0x3b, 0x76, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_v2_snowman_proto_rawDescOnce sync.Once
+	file_v2_snowman_proto_rawDescData = file_v2_snowman_proto_rawDesc
+)
+
+func file_v2_snowman_proto_rawDescGZIP() []byte {
+	file_v2_snowman_proto_rawDescOnce.Do(func() {
+		file_v2_snowman_proto_rawDescData = protoimpl.X.CompressGZIP(file_v2_snowman_proto_rawDescData)
+	})
+	return file_v2_snowman_proto_rawDescData
+}
+
+var file_v2_snowman_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_v2_snowman_proto_goTypes = []any{
+	(*Snowflake)(nil),             // 0: snowman.api.v2.Snowflake
+	(*SnowflakeParts)(nil),        // 1: snowman.api.v2.SnowflakeParts
+	(*IDLayout)(nil),              // 2: snowman.api.v2.IDLayout
+	(*ServerInfo)(nil),            // 3: snowman.api.v2.ServerInfo
+	(*SnowflakeRange)(nil),        // 4: snowman.api.v2.SnowflakeRange
+	(*SnowflakeRanges)(nil),       // 5: snowman.api.v2.SnowflakeRanges
+	(*SnowflakeList)(nil),         // 6: snowman.api.v2.SnowflakeList
+	(*BatchIDsRequest)(nil),       // 7: snowman.api.v2.BatchIDsRequest
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 9: google.protobuf.Empty
+}
+var file_v2_snowman_proto_depIdxs = []int32{
+	8,  // 0: snowman.api.v2.SnowflakeParts.time:type_name -> google.protobuf.Timestamp
+	2,  // 1: snowman.api.v2.ServerInfo.layout:type_name -> snowman.api.v2.IDLayout
+	8,  // 2: snowman.api.v2.ServerInfo.epoch:type_name -> google.protobuf.Timestamp
+	8,  // 3: snowman.api.v2.ServerInfo.logical_time:type_name -> google.protobuf.Timestamp
+	8,  // 4: snowman.api.v2.ServerInfo.lease_expires:type_name -> google.protobuf.Timestamp
+	4,  // 5: snowman.api.v2.SnowflakeRanges.ranges:type_name -> snowman.api.v2.SnowflakeRange
+	9,  // 6: snowman.api.v2.SnowflakeService.NextID:input_type -> google.protobuf.Empty
+	7,  // 7: snowman.api.v2.SnowflakeService.BatchNextID:input_type -> snowman.api.v2.BatchIDsRequest
+	7,  // 8: snowman.api.v2.SnowflakeService.NextIDs:input_type -> snowman.api.v2.BatchIDsRequest
+	7,  // 9: snowman.api.v2.SnowflakeService.ReserveRange:input_type -> snowman.api.v2.BatchIDsRequest
+	0,  // 10: snowman.api.v2.SnowflakeService.Decode:input_type -> snowman.api.v2.Snowflake
+	9,  // 11: snowman.api.v2.SnowflakeService.GetInfo:input_type -> google.protobuf.Empty
+	0,  // 12: snowman.api.v2.SnowflakeService.NextID:output_type -> snowman.api.v2.Snowflake
+	0,  // 13: snowman.api.v2.SnowflakeService.BatchNextID:output_type -> snowman.api.v2.Snowflake
+	6,  // 14: snowman.api.v2.SnowflakeService.NextIDs:output_type -> snowman.api.v2.SnowflakeList
+	5,  // 15: snowman.api.v2.SnowflakeService.ReserveRange:output_type -> snowman.api.v2.SnowflakeRanges
+	1,  // 16: snowman.api.v2.SnowflakeService.Decode:output_type -> snowman.api.v2.SnowflakeParts
+	3,  // 17: snowman.api.v2.SnowflakeService.GetInfo:output_type -> snowman.api.v2.ServerInfo
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_v2_snowman_proto_init() }
+func file_v2_snowman_proto_init() {
+	if File_v2_snowman_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_v2_snowman_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Snowflake); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_snowman_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*SnowflakeParts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_snowman_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*IDLayout); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_snowman_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ServerInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_snowman_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*SnowflakeRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_snowman_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*SnowflakeRanges); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_snowman_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*SnowflakeList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v2_snowman_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*BatchIDsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_v2_snowman_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_v2_snowman_proto_goTypes,
+		DependencyIndexes: file_v2_snowman_proto_depIdxs,
+		MessageInfos:      file_v2_snowman_proto_msgTypes,
+	}.Build()
+	File_v2_snowman_proto = out.File
+	file_v2_snowman_proto_rawDesc = nil
+	file_v2_snowman_proto_goTypes = nil
+	file_v2_snowman_proto_depIdxs = nil
+}
@@ -0,0 +1,330 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: v2/snowman.proto
+
+package v2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SnowflakeService_NextID_FullMethodName       = "/snowman.api.v2.SnowflakeService/NextID"
+	SnowflakeService_BatchNextID_FullMethodName  = "/snowman.api.v2.SnowflakeService/BatchNextID"
+	SnowflakeService_NextIDs_FullMethodName      = "/snowman.api.v2.SnowflakeService/NextIDs"
+	SnowflakeService_ReserveRange_FullMethodName = "/snowman.api.v2.SnowflakeService/ReserveRange"
+	SnowflakeService_Decode_FullMethodName       = "/snowman.api.v2.SnowflakeService/Decode"
+	SnowflakeService_GetInfo_FullMethodName      = "/snowman.api.v2.SnowflakeService/GetInfo"
+)
+
+// SnowflakeServiceClient is the client API for SnowflakeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SnowflakeServiceClient interface {
+	NextID(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Snowflake, error)
+	BatchNextID(ctx context.Context, in *BatchIDsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Snowflake], error)
+	// NextIDs returns length IDs in a single response. The server caps
+	// length, use BatchNextID or ReserveRange for bigger batches.
+	NextIDs(ctx context.Context, in *BatchIDsRequest, opts ...grpc.CallOption) (*SnowflakeList, error)
+	// ReserveRange reserves length IDs at once and returns them as ranges
+	// of consecutive IDs.
+	ReserveRange(ctx context.Context, in *BatchIDsRequest, opts ...grpc.CallOption) (*SnowflakeRanges, error)
+	// Decode breaks an ID into its fields, using the layout and epoch of
+	// the server.
+	Decode(ctx context.Context, in *Snowflake, opts ...grpc.CallOption) (*SnowflakeParts, error)
+	// GetInfo describes the generator behind the server.
+	GetInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServerInfo, error)
+}
+
+type snowflakeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSnowflakeServiceClient(cc grpc.ClientConnInterface) SnowflakeServiceClient {
+	return &snowflakeServiceClient{cc}
+}
+
+func (c *snowflakeServiceClient) NextID(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Snowflake, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Snowflake)
+	err := c.cc.Invoke(ctx, SnowflakeService_NextID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snowflakeServiceClient) BatchNextID(ctx context.Context, in *BatchIDsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Snowflake], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SnowflakeService_ServiceDesc.Streams[0], SnowflakeService_BatchNextID_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BatchIDsRequest, Snowflake]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SnowflakeService_BatchNextIDClient = grpc.ServerStreamingClient[Snowflake]
+
+func (c *snowflakeServiceClient) NextIDs(ctx context.Context, in *BatchIDsRequest, opts ...grpc.CallOption) (*SnowflakeList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SnowflakeList)
+	err := c.cc.Invoke(ctx, SnowflakeService_NextIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snowflakeServiceClient) ReserveRange(ctx context.Context, in *BatchIDsRequest, opts ...grpc.CallOption) (*SnowflakeRanges, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SnowflakeRanges)
+	err := c.cc.Invoke(ctx, SnowflakeService_ReserveRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snowflakeServiceClient) Decode(ctx context.Context, in *Snowflake, opts ...grpc.CallOption) (*SnowflakeParts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SnowflakeParts)
+	err := c.cc.Invoke(ctx, SnowflakeService_Decode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snowflakeServiceClient) GetInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServerInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerInfo)
+	err := c.cc.Invoke(ctx, SnowflakeService_GetInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SnowflakeServiceServer is the server API for SnowflakeService service.
+// All implementations must embed UnimplementedSnowflakeServiceServer
+// for forward compatibility.
+type SnowflakeServiceServer interface {
+	NextID(context.Context, *emptypb.Empty) (*Snowflake, error)
+	BatchNextID(*BatchIDsRequest, grpc.ServerStreamingServer[Snowflake]) error
+	// NextIDs returns length IDs in a single response. The server caps
+	// length, use BatchNextID or ReserveRange for bigger batches.
+	NextIDs(context.Context, *BatchIDsRequest) (*SnowflakeList, error)
+	// ReserveRange reserves length IDs at once and returns them as ranges
+	// of consecutive IDs.
+	ReserveRange(context.Context, *BatchIDsRequest) (*SnowflakeRanges, error)
+	// Decode breaks an ID into its fields, using the layout and epoch of
+	// the server.
+	Decode(context.Context, *Snowflake) (*SnowflakeParts, error)
+	// GetInfo describes the generator behind the server.
+	GetInfo(context.Context, *emptypb.Empty) (*ServerInfo, error)
+	mustEmbedUnimplementedSnowflakeServiceServer()
+}
+
+// UnimplementedSnowflakeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSnowflakeServiceServer struct{}
+
+func (UnimplementedSnowflakeServiceServer) NextID(context.Context, *emptypb.Empty) (*Snowflake, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextID not implemented")
+}
+func (UnimplementedSnowflakeServiceServer) BatchNextID(*BatchIDsRequest, grpc.ServerStreamingServer[Snowflake]) error {
+	return status.Errorf(codes.Unimplemented, "method BatchNextID not implemented")
+}
+func (UnimplementedSnowflakeServiceServer) NextIDs(context.Context, *BatchIDsRequest) (*SnowflakeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextIDs not implemented")
+}
+func (UnimplementedSnowflakeServiceServer) ReserveRange(context.Context, *BatchIDsRequest) (*SnowflakeRanges, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveRange not implemented")
+}
+func (UnimplementedSnowflakeServiceServer) Decode(context.Context, *Snowflake) (*SnowflakeParts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Decode not implemented")
+}
+func (UnimplementedSnowflakeServiceServer) GetInfo(context.Context, *emptypb.Empty) (*ServerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
+}
+func (UnimplementedSnowflakeServiceServer) mustEmbedUnimplementedSnowflakeServiceServer() {}
+func (UnimplementedSnowflakeServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeSnowflakeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SnowflakeServiceServer will
+// result in compilation errors.
+type UnsafeSnowflakeServiceServer interface {
+	mustEmbedUnimplementedSnowflakeServiceServer()
+}
+
+func RegisterSnowflakeServiceServer(s grpc.ServiceRegistrar, srv SnowflakeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSnowflakeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SnowflakeService_ServiceDesc, srv)
+}
+
+func _SnowflakeService_NextID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnowflakeServiceServer).NextID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SnowflakeService_NextID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnowflakeServiceServer).NextID(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnowflakeService_BatchNextID_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchIDsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SnowflakeServiceServer).BatchNextID(m, &grpc.GenericServerStream[BatchIDsRequest, Snowflake]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SnowflakeService_BatchNextIDServer = grpc.ServerStreamingServer[Snowflake]
+
+func _SnowflakeService_NextIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnowflakeServiceServer).NextIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SnowflakeService_NextIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnowflakeServiceServer).NextIDs(ctx, req.(*BatchIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnowflakeService_ReserveRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnowflakeServiceServer).ReserveRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SnowflakeService_ReserveRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnowflakeServiceServer).ReserveRange(ctx, req.(*BatchIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnowflakeService_Decode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Snowflake)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnowflakeServiceServer).Decode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SnowflakeService_Decode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnowflakeServiceServer).Decode(ctx, req.(*Snowflake))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnowflakeService_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnowflakeServiceServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SnowflakeService_GetInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnowflakeServiceServer).GetInfo(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SnowflakeService_ServiceDesc is the grpc.ServiceDesc for SnowflakeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SnowflakeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "snowman.api.v2.SnowflakeService",
+	HandlerType: (*SnowflakeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NextID",
+			Handler:    _SnowflakeService_NextID_Handler,
+		},
+		{
+			MethodName: "NextIDs",
+			Handler:    _SnowflakeService_NextIDs_Handler,
+		},
+		{
+			MethodName: "ReserveRange",
+			Handler:    _SnowflakeService_ReserveRange_Handler,
+		},
+		{
+			MethodName: "Decode",
+			Handler:    _SnowflakeService_Decode_Handler,
+		},
+		{
+			MethodName: "GetInfo",
+			Handler:    _SnowflakeService_GetInfo_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BatchNextID",
+			Handler:       _SnowflakeService_BatchNextID_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "v2/snowman.proto",
+}
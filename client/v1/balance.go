@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	_ "google.golang.org/grpc/health" // client side health checking
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
+)
+
+// healthService is the service the server reports its readiness under, see
+// server.HealthService.
+const healthService = "snowman.api.v1.SnowflakeService"
+
+// serviceConfig spreads the calls over all the servers of the target, and
+// takes the ones that report themselves as not serving out of rotation.
+var serviceConfig = fmt.Sprintf(`{
+	"loadBalancingConfig": [{"round_robin": {}}],
+	"healthCheckConfig": {"serviceName": %q}
+}`, healthService)
+
+const (
+	// maxAttempts is how many times a unary call is tried before its
+	// Unavailable error is returned.
+	maxAttempts    = 3
+	initialBackoff = 50 * time.Millisecond
+)
+
+// staticScheme is the resolver scheme of comma separated endpoint lists.
+const staticScheme = "snowman-static"
+
+// dialTarget turns the endpoints the client was given into a gRPC target.
+// A comma separated list of host:port is balanced over as is, a target
+// with a scheme, such as dns:///snowman:6996, is left to its resolver, and a
+// single host:port is resolved through DNS, so that all the A records of a
+// name are used.
+func dialTarget(endpoints string) (string, []grpc.DialOption, error) {
+	if strings.Contains(endpoints, "://") {
+		return endpoints, nil, nil
+	}
+	if !strings.Contains(endpoints, ",") {
+		return "dns:///" + endpoints, nil, nil
+	}
+	var addrs []resolver.Address
+	for _, e := range strings.Split(endpoints, ",") {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		host, _, err := net.SplitHostPort(e)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid endpoint %q: %v", e, err)
+		}
+		addrs = append(addrs, resolver.Address{Addr: e, ServerName: host})
+	}
+	if len(addrs) == 0 {
+		return "", nil, fmt.Errorf("no endpoint in %q", endpoints)
+	}
+	b := &staticBuilder{addrs: addrs}
+	return staticScheme + ":///" + endpoints, []grpc.DialOption{grpc.WithResolvers(b)}, nil
+}
+
+// staticBuilder resolves to a fixed list of addresses.
+type staticBuilder struct {
+	addrs []resolver.Address
+}
+
+func (b *staticBuilder) Build(_ resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	cc.UpdateState(resolver.State{Addresses: b.addrs})
+	return staticResolver{}, nil
+}
+
+func (b *staticBuilder) Scheme() string { return staticScheme }
+
+type staticResolver struct{}
+
+func (staticResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (staticResolver) Close() {}
+
+// retryUnavailable retries unary calls failing with Unavailable, which the
+// balancer sends to another server, so a client rides through the restart
+// of a server. The retry support of gRPC itself is still experimental and
+// disabled by default.
+func retryUnavailable(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if attempt == maxAttempts || status.Code(err) != codes.Unavailable {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -7,15 +7,32 @@ import (
 	"fmt"
 	"io/ioutil"
 
-	"github.com/gogo/protobuf/types"
 	v1 "github.com/thatique/snowman/api/v1"
+	v2 "github.com/thatique/snowman/api/v2"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// Idgen issues IDs, either remotely through a SnowmanClient or in-process
+// through an embedded.Embedded generator.
+type Idgen interface {
+	// NextID returns a new ID.
+	NextID(ctx context.Context) (v1.ID, error)
+	// NextIDs returns n new IDs, in increasing order. n is at most
+	// v1.MaxNextIDsLength, and a server may cap it lower; ask for more
+	// in several calls.
+	NextIDs(ctx context.Context, n int) ([]v1.ID, error)
+}
+
+var _ Idgen = (*SnowmanClient)(nil)
+
 // SnowmanClient is a client to Snowflake ID generator server
 type SnowmanClient struct {
-	c v1.SnowflakeServiceClient
+	conn    *grpc.ClientConn
+	c       v1.SnowflakeServiceClient
+	onClose []func()
 }
 
 // SnowmanCursor is cursor for iterating batch ID request
@@ -30,52 +47,120 @@ func (client *SnowmanCursor) Next() (v1.ID, error) {
 		return v1.ID(0), err
 	}
 
-	return snowflake.ID, nil
+	return snowflake.ID()
 }
 
-// NewSnowmanClient create snowflake client
-func NewSnowmanClient(hostAndPort, caPath, clientCrt, clientKey string) (*SnowmanClient, error) {
-	var opts []grpc.DialOption
+// Dial creates a snowflake client. target is either a single host:port,
+// whose A records are all used, a comma separated list of host:port, or a
+// gRPC target such as dns:///snowman:6996. Calls are balanced over the
+// servers that report themselves healthy, and retried on another server
+// when one is unavailable. The connection is insecure unless a TLS option
+// is given.
+func Dial(target string, opts ...Option) (*SnowmanClient, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	target, dialOpts, err := dialTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	dialOpts = append(dialOpts,
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithChainUnaryInterceptor(append([]grpc.UnaryClientInterceptor{retryUnavailable}, o.unary...)...),
+		grpc.WithChainStreamInterceptor(o.stream...),
+	)
+	if o.secure {
+		cfg, err := o.loadTLS()
+		if err != nil {
+			return nil, err
+		}
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(cfg)))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	conn, err := grpc.Dial(target, append(dialOpts, o.dialOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("dial: %v", err)
+	}
+	client := &SnowmanClient{conn: conn, c: v1.NewSnowflakeServiceClient(conn), onClose: o.onClose}
+	if o.apiV2 {
+		client.c = v2Client{c: v2.NewSnowflakeServiceClient(conn)}
+	}
+	return client, nil
+}
 
+// NewSnowmanClient create snowflake client, see Dial. If caPath is set, the
+// connection uses TLS with the CA at caPath and the client certificate
+// clientCrt and clientKey.
+func NewSnowmanClient(hostAndPort, caPath, clientCrt, clientKey string) (*SnowmanClient, error) {
+	var opts []Option
 	if caPath != "" {
+		opts = append(opts, WithRootCA(caPath), WithClientCert(clientCrt, clientKey))
+	}
+	return Dial(hostAndPort, opts...)
+}
+
+// loadTLS builds the TLS configuration of the connection.
+func (o *options) loadTLS() (*tls.Config, error) {
+	cfg := &tls.Config{}
+	if o.tlsConfig != nil {
+		cfg = o.tlsConfig.Clone()
+	}
+	if o.caFile != "" {
 		cPool := x509.NewCertPool()
-		caCert, err := ioutil.ReadFile(caPath)
+		caCert, err := ioutil.ReadFile(o.caFile)
 		if err != nil {
-			return nil, fmt.Errorf("invalid CA crt file: %s", caPath)
+			return nil, fmt.Errorf("invalid CA crt file: %s", o.caFile)
 		}
 		if cPool.AppendCertsFromPEM(caCert) != true {
 			return nil, fmt.Errorf("failed to parse CA crt")
 		}
-
-		clientCert, err := tls.LoadX509KeyPair(clientCrt, clientKey)
+		cfg.RootCAs = cPool
+	}
+	if o.certFile != "" || o.keyFile != "" {
+		clientCert, err := tls.LoadX509KeyPair(o.certFile, o.keyFile)
 		if err != nil {
-			return nil, fmt.Errorf("invalid client crt file: %s", caPath)
-		}
-
-		clientTLSConfig := &tls.Config{
-			RootCAs:      cPool,
-			Certificates: []tls.Certificate{clientCert},
+			return nil, fmt.Errorf("invalid client crt file: %s", o.certFile)
 		}
-		creds := credentials.NewTLS(clientTLSConfig)
-		opts = append(opts, grpc.WithTransportCredentials(creds))
-	} else {
-		opts = append(opts, grpc.WithInsecure())
+		cfg.Certificates = []tls.Certificate{clientCert}
 	}
-	conn, err := grpc.Dial(hostAndPort, opts...)
-	if err != nil {
-		return nil, fmt.Errorf("dial: %v", err)
+	if o.serverName != "" {
+		cfg.ServerName = o.serverName
 	}
-	return &SnowmanClient{c: v1.NewSnowflakeServiceClient(conn)}, nil
+	return cfg, nil
+}
+
+// Close closes the connection to the servers, then runs the functions given
+// with WithOnClose.
+func (client *SnowmanClient) Close() error {
+	err := client.conn.Close()
+	for _, f := range client.onClose {
+		f()
+	}
+	return err
 }
 
 // NextID get the nextID
 func (client *SnowmanClient) NextID(ctx context.Context) (v1.ID, error) {
-	snowflake, err := client.c.NextID(ctx, &types.Empty{})
+	snowflake, err := client.c.NextID(ctx, &emptypb.Empty{})
 	if err != nil {
 		return v1.ID(0), err
 	}
 
-	return snowflake.ID, nil
+	return snowflake.ID()
+}
+
+// NextIDs get n IDs in a single response. The server caps n at its
+// -max-batch, and at most v1.MaxNextIDsLength; use NextBatchIDs or
+// ReserveRange for more.
+func (client *SnowmanClient) NextIDs(ctx context.Context, n int) ([]v1.ID, error) {
+	list, err := client.c.NextIDs(ctx, &v1.BatchIDsRequest{Length: int32(n)})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.IDs(), nil
 }
 
 // NextBatchIDs get many batch at once
@@ -87,3 +172,31 @@ func (client *SnowmanClient) NextBatchIDs(ctx context.Context, length int) (*Sno
 
 	return &SnowmanCursor{c: srv}, nil
 }
+
+// ReserveRange reserves length IDs at once. They come back as ranges of
+// consecutive IDs: a range starting at ID s with count c holds s, s+1, ...
+// s+c-1.
+func (client *SnowmanClient) ReserveRange(ctx context.Context, length int) ([]*v1.SnowflakeRange, error) {
+	ranges, err := client.c.ReserveRange(ctx, &v1.BatchIDsRequest{Length: int32(length)})
+	if err != nil {
+		return nil, err
+	}
+
+	return ranges.GetRanges(), nil
+}
+
+// Decode breaks id into its fields, using the layout and epoch of the server
+func (client *SnowmanClient) Decode(ctx context.Context, id v1.ID) (v1.Parts, error) {
+	parts, err := client.c.Decode(ctx, v1.NewSnowflake(id))
+	if err != nil {
+		return v1.Parts{}, err
+	}
+
+	return parts.Parts(), nil
+}
+
+// Info describes the generator behind the server. Use ServerInfo.Parts to
+// decode IDs locally.
+func (client *SnowmanClient) Info(ctx context.Context) (*v1.ServerInfo, error) {
+	return client.c.GetInfo(ctx, &emptypb.Empty{})
+}
@@ -0,0 +1,106 @@
+// Package embedded issues snowman IDs in-process, either straight from a
+// generator or through a gRPC server running in the process. It is apart
+// from the client package so that only the programs generating IDs
+// themselves depend on the server.
+package embedded
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	v1 "github.com/thatique/snowman/api/v1"
+	client "github.com/thatique/snowman/client/v1"
+	"github.com/thatique/snowman/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var _ client.Idgen = (*Embedded)(nil)
+
+// Embedded issues IDs from a generator running in the process. The IDs are
+// only unique across processes if every generator has its own machine ID,
+// so configure gen the way the server would be, e.g. with a lease.
+type Embedded struct {
+	gen *server.Generator
+}
+
+// NewEmbedded creates an Idgen issuing IDs from gen.
+func NewEmbedded(gen *server.Generator) *Embedded {
+	return &Embedded{gen: gen}
+}
+
+// NextID returns a new ID.
+func (e *Embedded) NextID(ctx context.Context) (v1.ID, error) {
+	id, err := e.gen.Next(ctx)
+	if err != nil {
+		return v1.ID(0), err
+	}
+
+	return v1.ID(id), nil
+}
+
+// NextIDs returns n new IDs, reserving them in as few steps as possible.
+// Like the servers, it refuses more than v1.MaxNextIDsLength.
+func (e *Embedded) NextIDs(ctx context.Context, n int) ([]v1.ID, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid length %d; must be positive", n)
+	}
+	if n > v1.MaxNextIDsLength {
+		return nil, fmt.Errorf("invalid length %d; can't be more than %d", n, v1.MaxNextIDsLength)
+	}
+	ids := make([]v1.ID, 0, n)
+	for len(ids) < n {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		ranges, err := e.gen.Reserve(ctx, n-len(ids))
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range ranges {
+			for i := 0; i < r.Count; i++ {
+				ids = append(ids, v1.ID(r.Start+uint64(i)))
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+const inProcessBufferSize = 1 << 20
+
+// DialInProcess creates a client of s that talks to it through an in-memory
+// connection rather than the network, e.g. to test code using the client.
+// The connection is insecure, don't pass TLS options. Close stops the
+// in-process gRPC server.
+func DialInProcess(s *server.Server, opts ...client.Option) (*client.SnowmanClient, error) {
+	lis := bufconn.Listen(inProcessBufferSize)
+	gs := grpc.NewServer()
+	s.RegisterServices(gs)
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(gs, hs)
+	ctx, cancel := context.WithCancel(context.Background())
+	go s.WatchHealth(ctx, hs, time.Second)
+	go gs.Serve(lis)
+	stop := func() {
+		cancel()
+		gs.Stop()
+	}
+
+	opts = append(opts,
+		client.WithDialOptions(grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		})),
+		client.WithOnClose(stop),
+	)
+	c, err := client.Dial("passthrough:///bufnet", opts...)
+	if err != nil {
+		stop()
+		return nil, err
+	}
+	return c, nil
+}
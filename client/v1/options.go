@@ -0,0 +1,99 @@
+package client
+
+import (
+	"crypto/tls"
+
+	"google.golang.org/grpc"
+)
+
+// Option configures the connection Dial opens.
+type Option func(*options)
+
+type options struct {
+	secure     bool
+	tlsConfig  *tls.Config
+	caFile     string
+	certFile   string
+	keyFile    string
+	serverName string
+	apiV2      bool
+	dialOpts   []grpc.DialOption
+	unary      []grpc.UnaryClientInterceptor
+	stream     []grpc.StreamClientInterceptor
+	onClose    []func()
+}
+
+// WithTLSConfig connects over TLS with a copy of cfg. The other TLS options
+// apply on top of it.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(o *options) {
+		o.secure = true
+		o.tlsConfig = cfg
+	}
+}
+
+// WithSystemRoots connects over TLS, verifying the server against the
+// system root CAs. It is implied by the other TLS options.
+func WithSystemRoots() Option {
+	return func(o *options) {
+		o.secure = true
+	}
+}
+
+// WithRootCA connects over TLS, verifying the server against the CA
+// certificates in the PEM file at path instead of the system roots.
+func WithRootCA(path string) Option {
+	return func(o *options) {
+		o.secure = true
+		o.caFile = path
+	}
+}
+
+// WithClientCert connects over TLS, authenticating with the certificate and
+// key in the PEM files at certFile and keyFile.
+func WithClientCert(certFile, keyFile string) Option {
+	return func(o *options) {
+		o.secure = true
+		o.certFile = certFile
+		o.keyFile = keyFile
+	}
+}
+
+// WithServerName connects over TLS, expecting the server certificate to be
+// issued for name rather than for the host it is reached at.
+func WithServerName(name string) Option {
+	return func(o *options) {
+		o.secure = true
+		o.serverName = name
+	}
+}
+
+// WithDialOptions passes opts to grpc.Dial, after the ones of the client.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOpts = append(o.dialOpts, opts...)
+	}
+}
+
+// WithUnaryInterceptors intercepts the unary calls of the client. The
+// interceptors run in order, around each attempt of a retried call.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return func(o *options) {
+		o.unary = append(o.unary, interceptors...)
+	}
+}
+
+// WithStreamInterceptors intercepts the streaming calls of the client.
+func WithStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) Option {
+	return func(o *options) {
+		o.stream = append(o.stream, interceptors...)
+	}
+}
+
+// WithOnClose runs f when the client is closed, after its connection, e.g.
+// to stop the in-process server it was dialed to.
+func WithOnClose(f func()) Option {
+	return func(o *options) {
+		o.onClose = append(o.onClose, f)
+	}
+}
@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "github.com/thatique/snowman/api/v1"
+)
+
+// ErrPoolClosed is returned by Pool.Next once the pool is closed and its
+// buffer is empty.
+var ErrPoolClosed = errors.New("snowman: pool closed")
+
+const (
+	defaultPoolLow    = 256
+	defaultPoolHigh   = 1024
+	defaultPoolMaxAge = 5 * time.Second
+	refillTimeout     = 5 * time.Second
+)
+
+// PoolOption configures a Pool.
+type PoolOption func(*Pool)
+
+// WithWatermarks makes the pool refill its buffer up to high IDs whenever it
+// holds fewer than low. The defaults are 256 and 1024. high must not exceed
+// the largest batch the server issues.
+func WithWatermarks(low, high int) PoolOption {
+	return func(p *Pool) {
+		p.low = low
+		p.high = high
+	}
+}
+
+// WithMaxAge discards buffered IDs fetched more than d ago, so the IDs a pool
+// hands out stay roughly ordered by the time they are used. The default is 5
+// seconds.
+func WithMaxAge(d time.Duration) PoolOption {
+	return func(p *Pool) {
+		p.maxAge = d
+	}
+}
+
+// Pool hands out IDs from a local buffer, which it fills in the background
+// by reserving ranges of IDs from the server. Next only does a round trip
+// when the buffer ran dry. A Pool is safe for concurrent use.
+type Pool struct {
+	client    *SnowmanClient
+	low, high int
+	maxAge    time.Duration
+
+	mu     sync.Mutex
+	ranges []poolRange
+	size   int
+	err    error
+	closed bool
+	// filled is closed and replaced after every refill.
+	filled chan struct{}
+
+	refill chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// poolRange is what is left of a range reserved from the server.
+type poolRange struct {
+	next    uint64
+	count   int
+	expires time.Time
+}
+
+// NewPool creates a pool of IDs issued through client and starts filling
+// it. Call Close to stop it.
+func NewPool(client *SnowmanClient, opts ...PoolOption) (*Pool, error) {
+	p := &Pool{
+		client: client,
+		low:    defaultPoolLow,
+		high:   defaultPoolHigh,
+		maxAge: defaultPoolMaxAge,
+		filled: make(chan struct{}),
+		refill: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.low < 0 || p.high <= 0 || p.low >= p.high {
+		return nil, fmt.Errorf("invalid pool watermarks %d and %d; must be 0 ≤ low < high", p.low, p.high)
+	}
+	if p.maxAge <= 0 {
+		return nil, fmt.Errorf("invalid pool max age %s; must be positive", p.maxAge)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	go p.run(ctx)
+	return p, nil
+}
+
+// Next returns the next ID of the buffer. It only waits for the server when
+// the buffer is empty, and returns the error of the refill if that failed.
+func (p *Pool) Next() (v1.ID, error) {
+	waited := false
+	for {
+		p.mu.Lock()
+		p.prune(time.Now())
+		if p.size > 0 {
+			r := &p.ranges[0]
+			id := r.next
+			r.next++
+			r.count--
+			if r.count == 0 {
+				p.ranges = p.ranges[1:]
+			}
+			p.size--
+			if p.size < p.low {
+				p.wake()
+			}
+			p.mu.Unlock()
+			return v1.ID(id), nil
+		}
+		if p.closed {
+			p.mu.Unlock()
+			return 0, ErrPoolClosed
+		}
+		if waited && p.err != nil {
+			err := p.err
+			p.mu.Unlock()
+			return 0, err
+		}
+		filled := p.filled
+		p.wake()
+		p.mu.Unlock()
+
+		<-filled
+		waited = true
+	}
+}
+
+// Len returns the number of IDs in the buffer.
+func (p *Pool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prune(time.Now())
+	return p.size
+}
+
+// Close stops filling the pool. It doesn't close the client.
+func (p *Pool) Close() error {
+	p.cancel()
+	<-p.done
+	return nil
+}
+
+// run refills the buffer when asked to by Next, and regularly to replace the
+// IDs that got too old, until ctx is done.
+func (p *Pool) run(ctx context.Context) {
+	defer close(p.done)
+	ticker := time.NewTicker(p.maxAge / 2)
+	defer ticker.Stop()
+	for {
+		p.fill(ctx)
+		select {
+		case <-ctx.Done():
+			p.mu.Lock()
+			p.closed = true
+			close(p.filled)
+			p.mu.Unlock()
+			return
+		case <-p.refill:
+		case <-ticker.C:
+		}
+	}
+}
+
+// fill tops the buffer up to the high watermark if it fell below the low
+// one.
+func (p *Pool) fill(ctx context.Context) {
+	p.mu.Lock()
+	p.prune(time.Now())
+	want := 0
+	if p.size < p.low || p.size == 0 {
+		want = p.high - p.size
+	}
+	p.mu.Unlock()
+
+	var (
+		ranges []*v1.SnowflakeRange
+		err    error
+	)
+	// the IDs may have been issued at any point of the call, measure their
+	// age from its start.
+	fetched := time.Now()
+	if want > 0 {
+		rctx, cancel := context.WithTimeout(ctx, refillTimeout)
+		ranges, err = p.client.ReserveRange(rctx, want)
+		cancel()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, r := range ranges {
+		start, serr := r.StartID()
+		if serr != nil {
+			err = serr
+			break
+		}
+		p.ranges = append(p.ranges, poolRange{
+			next:    uint64(start),
+			count:   int(r.GetCount()),
+			expires: fetched.Add(p.maxAge),
+		})
+		p.size += int(r.GetCount())
+	}
+	p.err = err
+	if ctx.Err() == nil {
+		close(p.filled)
+		p.filled = make(chan struct{})
+	}
+}
+
+// prune drops the ranges that expired by now. Ranges are kept in the order
+// they were fetched, so the expired ones are at the front.
+func (p *Pool) prune(now time.Time) {
+	for len(p.ranges) > 0 && !now.Before(p.ranges[0].expires) {
+		p.size -= p.ranges[0].count
+		p.ranges = p.ranges[1:]
+	}
+}
+
+// wake asks the background goroutine to refill the buffer.
+func (p *Pool) wake() {
+	select {
+	case p.refill <- struct{}{}:
+	default:
+	}
+}
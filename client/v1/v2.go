@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+
+	v1 "github.com/thatique/snowman/api/v1"
+	v2 "github.com/thatique/snowman/api/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// WithAPIv2 makes the client talk to the servers through the v2 API, which
+// sends IDs as fixed64 numbers. The client keeps returning v1.ID, so code
+// can move to the v2 wire format before it moves off v1.ID. The servers
+// must serve the v2 API.
+func WithAPIv2() Option {
+	return func(o *options) {
+		o.apiV2 = true
+	}
+}
+
+// v2Client implements the v1 client interface on top of the v2 API.
+type v2Client struct {
+	c v2.SnowflakeServiceClient
+}
+
+var _ v1.SnowflakeServiceClient = v2Client{}
+
+func (c v2Client) NextID(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*v1.Snowflake, error) {
+	snowflake, err := c.c.NextID(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return v1.NewSnowflake(v1.ID(snowflake.GetId())), nil
+}
+
+func (c v2Client) BatchNextID(ctx context.Context, in *v1.BatchIDsRequest, opts ...grpc.CallOption) (v1.SnowflakeService_BatchNextIDClient, error) {
+	stream, err := c.c.BatchNextID(ctx, &v2.BatchIDsRequest{Length: in.GetLength()}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return batchNextIDClientV2{stream}, nil
+}
+
+// batchNextIDClientV2 receives the IDs of a v2 stream as v1 snowflakes.
+type batchNextIDClientV2 struct {
+	v2.SnowflakeService_BatchNextIDClient
+}
+
+func (stream batchNextIDClientV2) Recv() (*v1.Snowflake, error) {
+	snowflake, err := stream.SnowflakeService_BatchNextIDClient.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return v1.NewSnowflake(v1.ID(snowflake.GetId())), nil
+}
+
+func (c v2Client) NextIDs(ctx context.Context, in *v1.BatchIDsRequest, opts ...grpc.CallOption) (*v1.SnowflakeList, error) {
+	list, err := c.c.NextIDs(ctx, &v2.BatchIDsRequest{Length: in.GetLength()}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.SnowflakeList{Ids: list.GetIds()}, nil
+}
+
+func (c v2Client) ReserveRange(ctx context.Context, in *v1.BatchIDsRequest, opts ...grpc.CallOption) (*v1.SnowflakeRanges, error) {
+	ranges, err := c.c.ReserveRange(ctx, &v2.BatchIDsRequest{Length: in.GetLength()}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	resp := &v1.SnowflakeRanges{Ranges: make([]*v1.SnowflakeRange, len(ranges.GetRanges()))}
+	for i, r := range ranges.GetRanges() {
+		resp.Ranges[i] = v1.NewSnowflakeRange(v1.ID(r.GetStart()), int(r.GetCount()))
+	}
+	return resp, nil
+}
+
+func (c v2Client) Decode(ctx context.Context, in *v1.Snowflake, opts ...grpc.CallOption) (*v1.SnowflakeParts, error) {
+	id, err := in.ID()
+	if err != nil {
+		return nil, err
+	}
+	parts, err := c.c.Decode(ctx, &v2.Snowflake{Id: uint64(id)}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.SnowflakeParts{
+		Time:         parts.GetTime(),
+		DatacenterId: parts.GetDatacenterId(),
+		MachineId:    parts.GetMachineId(),
+		Sequence:     parts.GetSequence(),
+	}, nil
+}
+
+func (c v2Client) GetInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*v1.ServerInfo, error) {
+	info, err := c.c.GetInfo(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ServerInfo{
+		Layout: &v1.IDLayout{
+			TimeBits:       info.GetLayout().GetTimeBits(),
+			DatacenterBits: info.GetLayout().GetDatacenterBits(),
+			MachineBits:    info.GetLayout().GetMachineBits(),
+			SequenceBits:   info.GetLayout().GetSequenceBits(),
+		},
+		Epoch:             info.GetEpoch(),
+		MachineId:         info.GetMachineId(),
+		Version:           info.GetVersion(),
+		LogicalTime:       info.GetLogicalTime(),
+		SequenceRemaining: info.GetSequenceRemaining(),
+		LeaseExpires:      info.GetLeaseExpires(),
+	}, nil
+}
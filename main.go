@@ -1,36 +1,63 @@
 package main
 
 import (
+	"context"
+	crand "crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	v1 "github.com/thatique/snowman/api/v1"
 	"github.com/thatique/snowman/server"
+	"github.com/thatique/snowman/server/lease"
 )
 
 var (
-	certFile = flag.String("cert-file", "", "The TLS cert file")
-	keyFile  = flag.String("key-file", "", "The TLS key file")
-	clientCA = flag.String("client-ca", "", "The TLS client CA")
-	gRPCPort = flag.Int("grpc-port", 6996, "The gRPC server port")
+	certFile      = flag.String("cert-file", "", "The TLS cert file")
+	keyFile       = flag.String("key-file", "", "The TLS key file")
+	clientCA      = flag.String("client-ca", "", "The TLS client CA")
+	gRPCPort      = flag.Int("grpc-port", 6996, "The gRPC server port")
+	httpPort      = flag.Int("http-port", 6997, "The HTTP/JSON gateway port, 0 disables the gateway")
+	metricsPort   = flag.Int("metrics-port", 6998, "The Prometheus metrics port, 0 disables metrics")
+	layout        = flag.String("layout", "default", "The ID bit layout: default, sonyflake, twitter, time/machine/sequence or time/datacenter/machine/sequence")
+	dcID          = flag.Int("datacenter-id", 0, "The datacenter ID, for layouts with a datacenter field")
+	machine       = flag.Int("machine-id", -1, "The machine ID, defaults to $SNOWMAN_MACHINE_ID")
+	machineSource = flag.String("machine-id-source", "static", "Where the machine ID comes from: static, statefulset, pod-ip, node-name, lease or random")
+	leaseDir      = flag.String("lease-dir", "", "The directory shared by all replicas to lease machine IDs from, with -machine-id-source=lease")
+	leaseTTL      = flag.Duration("lease-ttl", 10*time.Second, "How long a machine ID lease lives without being renewed")
+	epoch         = flag.String("epoch", "", "The ID epoch, as RFC3339 or milliseconds since the Unix epoch")
+	rollback      = flag.String("clock-rollback", "drift", "What to do when the clock goes backwards: drift, block or fail")
+	maxDrift      = flag.Duration("max-drift", server.DefaultMaxDrift, "How far the logical clock may run ahead of the wall clock with -clock-rollback=drift")
+	stateFile     = flag.String("state-file", "", "The file persisting the high-water timestamp across restarts")
+	stateInterval = flag.Duration("state-interval", time.Second, "How often the state file is written")
+	stateMaxWait  = flag.Duration("state-max-wait", 10*time.Second, "How long to wait at startup for the clock to pass the persisted high-water mark")
+	maxBatch      = flag.Int("max-batch", server.DefaultMaxBatchLength, fmt.Sprintf("The largest number of IDs a single BatchNextID, ReserveRange or NextIDs request may ask for; NextIDs is also capped at %d", server.MaxNextIDsLength))
+	idEncoding    = flag.String("id-encoding", "hex", "How the HTTP/JSON gateway encodes IDs: hex, decimal, base32, base58, base62 or base64url")
+	shutdownGrace = flag.Duration("shutdown-grace", 20*time.Second, "How long in-flight requests get to complete on shutdown")
 )
 
 var (
-	machineID int
-	log       grpclog.LoggerV2
+	log grpclog.LoggerV2
 	// this channel gets notified when process receives signal. It is global to ease unit testing
 	quit = make(chan os.Signal, 1)
 )
@@ -39,17 +66,106 @@ func init() {
 	log = grpclog.NewLoggerV2(os.Stdout, ioutil.Discard, ioutil.Discard)
 	grpclog.SetLoggerV2(log)
 	rand.Seed(time.Now().UnixNano())
-	machineID = rand.Intn(1023)
 }
 
 func main() {
 	flag.Parse()
+	// ctx is cancelled once the servers are stopped, it ends the background
+	// tasks tracked by tasks.
+	ctx, cancel := context.WithCancel(context.Background())
+	var tasks sync.WaitGroup
+
+	l, err := server.ParseLayout(*layout)
+	if err != nil {
+		log.Fatalf("invalid config: %v", err)
+	}
+	if *maxBatch <= 0 {
+		log.Fatalf("invalid config: max batch must be positive, got %d", *maxBatch)
+	}
+	jsonEncoding, err := v1.LookupEncoding(*idEncoding)
+	if err != nil {
+		log.Fatalf("invalid config: %v", err)
+	}
+	var (
+		m      int
+		keeper *lease.Keeper
+	)
+	if *machineSource == "lease" {
+		keeper, err = acquireLease(l)
+		if err != nil {
+			log.Fatalf("Failed to lease a machine ID: %v", err)
+		}
+		m = keeper.Lease().MachineID
+		tasks.Add(1)
+		go func() {
+			defer tasks.Done()
+			if err := keeper.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Errorf("Machine ID lease lost, refusing to issue IDs: %v", err)
+			}
+		}()
+	} else {
+		m, err = resolveMachineID(l)
+		if err != nil {
+			log.Fatalf("invalid config: %v", err)
+		}
+	}
+	machineID, err := l.NodeID(*dcID, m)
+	if err != nil {
+		log.Fatalf("invalid config: %v", err)
+	}
+	policy, err := server.ParseRollbackPolicy(*rollback)
+	if err != nil {
+		log.Fatalf("invalid config: %v", err)
+	}
+	genOpts := []server.Option{
+		server.WithLayout(l),
+		server.WithRollbackPolicy(policy, *maxDrift),
+	}
+	if keeper != nil {
+		genOpts = append(genOpts, server.WithLease(keeper))
+	}
+	if *metricsPort != 0 {
+		metrics := server.NewMetrics()
+		prometheus.MustRegister(metrics)
+		genOpts = append(genOpts, server.WithMetrics(metrics))
+	}
+	if *epoch != "" {
+		e, err := server.ParseEpoch(*epoch)
+		if err != nil {
+			log.Fatalf("invalid config: %v", err)
+		}
+		genOpts = append(genOpts, server.WithEpoch(e))
+	}
+	if *stateFile != "" {
+		hw, err := server.ReadStateFile(*stateFile)
+		if err != nil {
+			log.Fatalf("Failed to read state file: %v", err)
+		}
+		genOpts = append(genOpts, server.WithHighWater(hw, *stateMaxWait))
+	}
+	gen, err := server.NewGenerator(machineID, genOpts...)
+	if err != nil {
+		log.Fatalf("Failed to create generator: %v", err)
+	}
+	if *stateFile != "" {
+		tasks.Add(1)
+		go func() {
+			defer tasks.Done()
+			if err := gen.PersistState(ctx, *stateFile, *stateInterval); err != nil && !errors.Is(err, context.Canceled) {
+				log.Fatalf("Failed to persist state: %v", err)
+			}
+		}()
+	}
+
 	addr := fmt.Sprintf(":%d", *gRPCPort)
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		log.Fatalln("Failed to listen:", err)
 	}
-	var opts []grpc.ServerOption
+	var (
+		opts      []grpc.ServerOption
+		tlsConfig *tls.Config
+	)
 
 	allowedTLSCiphers := []uint16{
 		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
@@ -70,7 +186,7 @@ func main() {
 		if err != nil {
 			log.Fatalf("Failed to generate credentials %v", err)
 		}
-		tlsConfig := tls.Config{
+		tlsConfig = &tls.Config{
 			Certificates:             []tls.Certificate{cert},
 			MinVersion:               tls.VersionTLS12,
 			CipherSuites:             allowedTLSCiphers,
@@ -91,25 +207,183 @@ func main() {
 			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
 			tlsConfig.ClientCAs = cPool
 		}
-		opts = append(opts, grpc.Creds(credentials.NewTLS(&tlsConfig)))
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
 	}
+	srv := server.New(gen, server.WithMaxBatchLength(*maxBatch))
 	s := grpc.NewServer(opts...)
-	v1.RegisterSnowflakeServiceServer(s, server.New(machineID))
+	srv.RegisterServices(s)
+	hc := health.NewServer()
+	healthpb.RegisterHealthServer(s, hc)
+	tasks.Add(1)
+	go func() {
+		defer tasks.Done()
+		srv.WatchHealth(ctx, hc, time.Second)
+	}()
 	// Serve gRPC Server
 	log.Info("Serving gRPC on", addr)
 
 	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
-	serveErr := make(chan error)
+	serveErr := make(chan error, 1)
 
 	go func() {
 		serveErr <- s.Serve(lis)
 	}()
+
+	var hs *http.Server
+	httpErr := make(chan error, 1)
+	if *httpPort != 0 {
+		gw, err := server.NewGateway(ctx, srv, server.WithIDEncoding(jsonEncoding))
+		if err != nil {
+			log.Fatalf("Failed to create HTTP gateway: %v", err)
+		}
+		httpAddr := fmt.Sprintf(":%d", *httpPort)
+		hs = &http.Server{Addr: httpAddr, Handler: gw, TLSConfig: tlsConfig}
+		log.Info("Serving HTTP on", httpAddr)
+		go func() {
+			if tlsConfig != nil {
+				httpErr <- hs.ListenAndServeTLS("", "")
+			} else {
+				httpErr <- hs.ListenAndServe()
+			}
+		}()
+	}
+
+	var ms *http.Server
+	if *metricsPort != 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		metricsAddr := fmt.Sprintf(":%d", *metricsPort)
+		ms = &http.Server{Addr: metricsAddr, Handler: mux}
+		log.Info("Serving metrics on", metricsAddr)
+		go func() {
+			httpErr <- ms.ListenAndServe()
+		}()
+	}
+
 	select {
 	case err := <-serveErr:
 		log.Fatalf("Failed to start gRPC server: %v", err)
 
+	case err := <-httpErr:
+		log.Fatalf("Failed to start HTTP server: %v", err)
+
 	case <-quit:
 		// shutdown the server with a grace period of configured timeout
 		log.Info("stopping gRPC server ")
+		shutdown(s, hc, hs, ms)
+	}
+	cancel()
+	tasks.Wait()
+}
+
+// shutdown takes the servers out of rotation and drains them: health checks
+// report NOT_SERVING, new RPCs are refused and in-flight ones, including
+// BatchNextID streams, get until -shutdown-grace to complete before the
+// remaining connections are closed.
+func shutdown(s *grpc.Server, hc *health.Server, https ...*http.Server) {
+	hc.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *shutdownGrace)
+	defer cancel()
+	for _, hs := range https {
+		if hs == nil {
+			continue
+		}
+		if err := hs.Shutdown(ctx); err != nil {
+			log.Warningf("Failed to drain HTTP server: %v", err)
+			hs.Close()
+		}
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Warning("shutdown grace period expired, closing remaining connections")
+		s.Stop()
+	}
+}
+
+// acquireLease claims a free machine ID from the file store in -lease-dir.
+func acquireLease(l server.Layout) (*lease.Keeper, error) {
+	if *leaseDir == "" {
+		return nil, errors.New("-lease-dir is required with -machine-id-source=lease")
+	}
+	store, err := lease.NewFileStore(*leaseDir)
+	if err != nil {
+		return nil, err
+	}
+	holder, err := leaseHolder()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *leaseTTL)
+	defer cancel()
+	return lease.Acquire(ctx, store, holder, 1<<l.MachineBits-1, *leaseTTL)
+}
+
+// leaseHolder names this process in the lease store. The hostname alone
+// isn't unique: several processes may run on one host, or several
+// hostNetwork pods on one node, so the pid and a random suffix are added.
+func leaseHolder() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+	var suffix [4]byte
+	if _, err := crand.Read(suffix[:]); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%d-%x", hostname, os.Getpid(), suffix), nil
+}
+
+// resolveMachineID returns the machine ID from the source selected with
+// -machine-id-source. Only the random source isn't deterministic and it has
+// to be asked for explicitly.
+func resolveMachineID(l server.Layout) (int, error) {
+	switch *machineSource {
+	case "static":
+		if *machine >= 0 {
+			return *machine, nil
+		}
+		if env := os.Getenv("SNOWMAN_MACHINE_ID"); env != "" {
+			id, err := strconv.Atoi(env)
+			if err != nil {
+				return 0, fmt.Errorf("invalid SNOWMAN_MACHINE_ID %q: %v", env, err)
+			}
+			return id, nil
+		}
+		return 0, errors.New("no machine ID: set -machine-id or SNOWMAN_MACHINE_ID, or pick another -machine-id-source")
+
+	case "statefulset":
+		hostname, err := os.Hostname()
+		if err != nil {
+			return 0, err
+		}
+		return server.MachineIDFromOrdinal(hostname, l)
+
+	case "pod-ip":
+		env := os.Getenv("POD_IP")
+		ip := net.ParseIP(env)
+		if ip == nil {
+			return 0, fmt.Errorf("invalid POD_IP %q: expose the pod IP through the downward API", env)
+		}
+		return server.MachineIDFromIP(ip, l)
+
+	case "node-name":
+		name := os.Getenv("NODE_NAME")
+		if name == "" {
+			return 0, errors.New("NODE_NAME is empty: expose the node name through the downward API")
+		}
+		return server.MachineIDFromName(name, l), nil
+
+	case "random":
+		log.Warning("using a random machine ID, replicas may collide")
+		return rand.Intn(1 << l.MachineBits), nil
 	}
+	return 0, fmt.Errorf("invalid machine ID source %q", *machineSource)
 }
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// epochHeadroom is the minimum lifetime an epoch must leave to the time
+// field of a layout. Epochs closer than that to overflowing are rejected.
+const epochHeadroom = 365 * 24 * time.Hour
+
+var (
+	// DefaultEpoch is the epoch used when none is configured.
+	DefaultEpoch = time.Unix(0, 1491696000000*int64(time.Millisecond)).UTC()
+
+	// TwitterEpoch is the epoch of Twitter's snowflake IDs.
+	TwitterEpoch = time.Unix(0, 1288834974657*int64(time.Millisecond)).UTC()
+)
+
+// ParseEpoch parses an epoch given either as an RFC3339 timestamp or as
+// milliseconds since the Unix epoch.
+func ParseEpoch(s string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(0, ms*int64(time.Millisecond)).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid epoch %q: must be RFC3339 or milliseconds since the Unix epoch", s)
+	}
+	return t, nil
+}
+
+// validateEpoch checks that epoch is between the Unix epoch and now, and
+// that the time field of l still has room to grow from it.
+func validateEpoch(epoch time.Time, l Layout, now time.Time) error {
+	if epoch.Before(time.Unix(0, 0)) {
+		return fmt.Errorf("invalid epoch %s: it is before the Unix epoch", epoch.Format(time.RFC3339))
+	}
+	if epoch.After(now) {
+		return fmt.Errorf("invalid epoch %s: it is in the future", epoch.Format(time.RFC3339))
+	}
+	elapsed := uint64(now.Sub(epoch) / time.Millisecond)
+	capacity := uint64(1) << l.TimeBits
+	if elapsed >= capacity || capacity-elapsed < uint64(epochHeadroom/time.Millisecond) {
+		return fmt.Errorf("invalid epoch %s: the %d bit time field overflows in less than a year",
+			epoch.Format(time.RFC3339), l.TimeBits)
+	}
+	return nil
+}
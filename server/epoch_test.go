@@ -0,0 +1,36 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateEpoch(t *testing.T) {
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		epoch  string
+		layout Layout
+		ok     bool
+	}{
+		{"default", "1491696000000", DefaultLayout, true},
+		{"unix epoch", "0", DefaultLayout, true},
+		{"rfc3339", "2020-01-01T00:00:00Z", SonyflakeLayout, true},
+		{"negative ms", "-1", DefaultLayout, false},
+		{"before 1970", "1960-01-01T00:00:00Z", DefaultLayout, false},
+		{"future", "2027-01-01T00:00:00Z", DefaultLayout, false},
+		{"no headroom", "0", Layout{TimeBits: 40, MachineBits: 11, SequenceBits: 12}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			epoch, err := ParseEpoch(tt.epoch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = validateEpoch(epoch, tt.layout, now)
+			if (err == nil) != tt.ok {
+				t.Errorf("validateEpoch(%s, %s) = %v, want ok %v", epoch, tt.layout, err, tt.ok)
+			}
+		})
+	}
+}
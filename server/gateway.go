@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/thatique/snowman/api/v1"
+	"github.com/thatique/snowman/api/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const gatewayBufferSize = 1 << 20
+
+// GatewayOption configures the handler created by NewGateway.
+type GatewayOption func(*idJSON)
+
+// WithIDEncoding makes the gateway write and read the IDs of v1 messages
+// with enc rather than v1.Hex.
+func WithIDEncoding(enc v1.Encoding) GatewayOption {
+	return func(m *idJSON) {
+		m.enc = enc
+	}
+}
+
+// NewGateway returns an HTTP handler serving the REST/JSON API of s, e.g.
+// GET /v1/id and GET /v1/ids?length=N, and the same under /v2. The gateway
+// reaches s through an in-process gRPC server, so it doesn't need
+// credentials for the public one; that server is stopped when ctx is done.
+func NewGateway(ctx context.Context, s *Server, opts ...GatewayOption) (http.Handler, error) {
+	marshaler := &idJSON{
+		JSONPb: runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+		},
+		enc: v1.Hex,
+	}
+	for _, opt := range opts {
+		opt(marshaler)
+	}
+
+	lis := bufconn.Listen(gatewayBufferSize)
+	internal := grpc.NewServer()
+	s.RegisterServices(internal)
+	go internal.Serve(lis)
+	go func() {
+		<-ctx.Done()
+		internal.Stop()
+	}()
+
+	mux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, marshaler))
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		internal.Stop()
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+	if err := v1.RegisterSnowflakeServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	if err := v2.RegisterSnowflakeServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	return mux, nil
+}
+
+// idJSON is the JSON marshaler of the gateway. Protobuf JSON encodes the
+// bytes holding the IDs of v1 messages in base64; idJSON writes and reads
+// the IDs of these messages as strings encoded with enc instead.
+type idJSON struct {
+	runtime.JSONPb
+	enc v1.Encoding
+}
+
+// idMarshaler and idUnmarshaler are the v1 messages holding IDs.
+type idMarshaler interface {
+	MarshalJSONEncoding(enc v1.Encoding) ([]byte, error)
+}
+
+type idUnmarshaler interface {
+	UnmarshalJSONEncoding(data []byte, enc v1.Encoding) error
+}
+
+func (m *idJSON) Marshal(v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case idMarshaler:
+		return v.MarshalJSONEncoding(m.enc)
+	case map[string]interface{}:
+		// the {"result": message} chunks of streams.
+		chunk := make(map[string]json.RawMessage, len(v))
+		for k, e := range v {
+			b, err := m.Marshal(e)
+			if err != nil {
+				return nil, err
+			}
+			chunk[k] = b
+		}
+		return json.Marshal(chunk)
+	}
+	return m.JSONPb.Marshal(v)
+}
+
+func (m *idJSON) Unmarshal(data []byte, v interface{}) error {
+	if u, ok := v.(idUnmarshaler); ok {
+		return u.UnmarshalJSONEncoding(data, m.enc)
+	}
+	return m.JSONPb.Unmarshal(data, v)
+}
+
+func (m *idJSON) NewDecoder(r io.Reader) runtime.Decoder {
+	dec := m.JSONPb.NewDecoder(r)
+	return runtime.DecoderFunc(func(v interface{}) error {
+		if u, ok := v.(idUnmarshaler); ok {
+			var data json.RawMessage
+			if err := json.NewDecoder(r).Decode(&data); err != nil {
+				return err
+			}
+			return u.UnmarshalJSONEncoding(data, m.enc)
+		}
+		return dec.Decode(v)
+	})
+}
+
+func (m *idJSON) NewEncoder(w io.Writer) runtime.Encoder {
+	return runtime.EncoderFunc(func(v interface{}) error {
+		b, err := m.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(b, m.Delimiter()...))
+		return err
+	})
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/thatique/snowman/api/v1"
+)
+
+func TestGatewayIDEncoding(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	g, err := NewGenerator(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gw, err := NewGateway(ctx, New(g), WithIDEncoding(v1.Base62))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(gw)
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/v1/id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var body struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	id, err := v1.Base62.Decode(body.ID)
+	if err != nil || len(body.ID) != len(v1.Base62.Encode(0)) {
+		t.Fatalf("GET /v1/id returned ID %q, want a base62 ID: %v", body.ID, err)
+	}
+
+	// the IDs posted to the gateway are read with its encoding too.
+	res, err = http.Post(ts.URL+"/v1/decode", "application/json", strings.NewReader(`{"id":"`+body.ID+`"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var parts struct {
+		MachineID int `json:"machine_id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&parts); err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK || parts.MachineID != 1 {
+		t.Errorf("POST /v1/decode of %d: %s, machine ID %d; want 200 OK and machine ID 1", id, res.Status, parts.MachineID)
+	}
+
+	// the encoding of the gateway doesn't leak into the JSON of IDs.
+	if data, _ := json.Marshal(id); string(data) != `"`+id.String()+`"` {
+		t.Errorf("json.Marshal(%d) = %s, want its hex string", id, data)
+	}
+}
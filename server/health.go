@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthService and HealthServiceV2 are the service names health checks
+// report the v1 and v2 ID services under.
+const (
+	HealthService   = "snowman.api.v1.SnowflakeService"
+	HealthServiceV2 = "snowman.api.v2.SnowflakeService"
+)
+
+// WatchHealth keeps the status hs reports for the server, and for
+// HealthService and HealthServiceV2, in line with the readiness of the
+// generator: a node that can't issue unique IDs reports NOT_SERVING and gets
+// pulled from rotation. It checks every interval until ctx is done.
+func (s *Server) WatchHealth(ctx context.Context, hs *health.Server, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var last error
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		err := s.gen.Ready()
+		if err != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if (err == nil) != (last == nil) {
+			if err != nil {
+				grpclog.Warningf("generator not ready: %v", err)
+			} else {
+				grpclog.Info("generator ready")
+			}
+		}
+		last = err
+		hs.SetServingStatus("", status)
+		hs.SetServingStatus(HealthService, status)
+		hs.SetServingStatus(HealthServiceV2, status)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package server
+
+import "github.com/thatique/snowman/api/v1"
+
+// Layout describes how the bits of an ID are split, see v1.Layout.
+type Layout = v1.Layout
+
+var (
+	// DefaultLayout is the original snowman split, see v1.DefaultLayout.
+	DefaultLayout = v1.DefaultLayout
+
+	// SonyflakeLayout favours many machines, see v1.SonyflakeLayout.
+	SonyflakeLayout = v1.SonyflakeLayout
+
+	// TwitterLayout has a datacenter field, see v1.TwitterLayout.
+	TwitterLayout = v1.TwitterLayout
+)
+
+// ParseLayout parses a layout by name or bit widths, see v1.ParseLayout.
+func ParseLayout(s string) (Layout, error) {
+	return v1.ParseLayout(s)
+}
@@ -0,0 +1,190 @@
+//go:build !windows
+// +build !windows
+
+package lease
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const leaseExt = ".lease"
+
+// FileStore is a LeaseStore keeping one file per leased machine ID in a
+// directory shared by every replica, such as a volume mounted by all pods.
+// Operations are serialized with flock(2) on a lock file in that directory,
+// so the shared filesystem must support it.
+type FileStore struct {
+	dir string
+}
+
+var _ LeaseStore = (*FileStore)(nil)
+
+type fileLease struct {
+	Holder  string    `json:"holder"`
+	Expires time.Time `json:"expires"`
+}
+
+// NewFileStore creates a FileStore in dir, creating the directory if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Acquire implements LeaseStore.
+func (s *FileStore) Acquire(_ context.Context, holder string, maxID int, ttl time.Duration) (Lease, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return Lease{}, err
+	}
+	defer unlock()
+
+	leases, err := s.readAll()
+	if err != nil {
+		return Lease{}, err
+	}
+	now := time.Now()
+	id, ok := pick(func(id int) (Lease, bool) {
+		l, ok := leases[id]
+		return l, ok
+	}, holder, maxID, now)
+	if !ok {
+		return Lease{}, ErrNoFreeID
+	}
+	l := Lease{MachineID: id, Holder: holder, Expires: now.Add(ttl)}
+	return l, s.write(l)
+}
+
+// Renew implements LeaseStore.
+func (s *FileStore) Renew(_ context.Context, l Lease, ttl time.Duration) (Lease, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return Lease{}, err
+	}
+	defer unlock()
+
+	cur, err := s.read(l.MachineID)
+	if os.IsNotExist(err) {
+		return Lease{}, ErrLeaseLost
+	}
+	if err != nil {
+		return Lease{}, err
+	}
+	now := time.Now()
+	if cur.Holder != l.Holder || !now.Before(cur.Expires) {
+		return Lease{}, ErrLeaseLost
+	}
+	cur.Expires = now.Add(ttl)
+	return cur, s.write(cur)
+}
+
+// Release implements LeaseStore.
+func (s *FileStore) Release(_ context.Context, l Lease) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	cur, err := s.read(l.MachineID)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if cur.Holder != l.Holder {
+		return nil
+	}
+	if time.Now().Before(l.Expires) {
+		cur.Expires = l.Expires
+		return s.write(cur)
+	}
+	return os.Remove(s.path(l.MachineID))
+}
+
+func (s *FileStore) lock() (func(), error) {
+	f, err := os.OpenFile(filepath.Join(s.dir, ".lock"), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("lease: locking %s: %v", s.dir, err)
+	}
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+func (s *FileStore) path(id int) string {
+	return filepath.Join(s.dir, strconv.Itoa(id)+leaseExt)
+}
+
+func (s *FileStore) read(id int) (Lease, error) {
+	data, err := ioutil.ReadFile(s.path(id))
+	if err != nil {
+		return Lease{}, err
+	}
+	var fl fileLease
+	if err := json.Unmarshal(data, &fl); err != nil {
+		return Lease{}, fmt.Errorf("lease: invalid lease file %s: %v", s.path(id), err)
+	}
+	return Lease{MachineID: id, Holder: fl.Holder, Expires: fl.Expires}, nil
+}
+
+func (s *FileStore) readAll() (map[int]Lease, error) {
+	entries, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	leases := make(map[int]Lease, len(entries))
+	for _, e := range entries {
+		id, err := strconv.Atoi(strings.TrimSuffix(e.Name(), leaseExt))
+		if err != nil || !strings.HasSuffix(e.Name(), leaseExt) {
+			continue
+		}
+		l, err := s.read(id)
+		if err != nil {
+			return nil, err
+		}
+		leases[id] = l
+	}
+	return leases, nil
+}
+
+// write durably replaces the lease file of l.
+func (s *FileStore) write(l Lease) error {
+	data, err := json.Marshal(fileLease{Holder: l.Holder, Expires: l.Expires})
+	if err != nil {
+		return err
+	}
+	path := s.path(l.MachineID)
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,107 @@
+//go:build !windows
+// +build !windows
+
+package lease
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestFileStoreContention races the replicas of a fleet, each with its own
+// FileStore on a shared directory, for fewer machine IDs than replicas.
+func TestFileStoreContention(t *testing.T) {
+	const replicas, maxID = 8, 3
+	dir := t.TempDir()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		leased  = make(map[int]string)
+		refused int
+	)
+	for i := 0; i < replicas; i++ {
+		s, err := NewFileStore(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		holder := string(rune('a' + i))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l, err := s.Acquire(context.Background(), holder, maxID, time.Minute)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case errors.Is(err, ErrNoFreeID):
+				refused++
+			case err != nil:
+				t.Error(err)
+			case leased[l.MachineID] != "":
+				t.Errorf("machine ID %d leased to %s and %s", l.MachineID, leased[l.MachineID], holder)
+			default:
+				leased[l.MachineID] = holder
+			}
+		}()
+	}
+	wg.Wait()
+	if len(leased) != maxID+1 || refused != replicas-maxID-1 {
+		t.Errorf("%d IDs leased and %d replicas refused, want %d and %d", len(leased), refused, maxID+1, replicas-maxID-1)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	a, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := a.Acquire(ctx, "a", 0, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Acquire(ctx, "b", 0, time.Minute); !errors.Is(err, ErrNoFreeID) {
+		t.Errorf("Acquire of a live lease: got %v, want ErrNoFreeID", err)
+	}
+	if l, err = a.Renew(ctx, l, 50*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if _, err := a.Renew(ctx, l, time.Minute); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Renew of an expired lease: got %v, want ErrLeaseLost", err)
+	}
+	taken, err := b.Acquire(ctx, "b", 0, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a's late release leaves b's lease alone.
+	if err := a.Release(ctx, l); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Renew(ctx, taken, time.Minute); err != nil {
+		t.Errorf("Renew after another holder's release: %v", err)
+	}
+
+	// a release ahead of the clock holds the ID until then.
+	taken.Expires = time.Now().Add(100 * time.Millisecond)
+	if err := b.Release(ctx, taken); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Acquire(ctx, "a", 0, time.Minute); !errors.Is(err, ErrNoFreeID) {
+		t.Errorf("Acquire of a held ID: got %v, want ErrNoFreeID", err)
+	}
+	time.Sleep(time.Until(taken.Expires))
+	if _, err := a.Acquire(ctx, "a", 0, time.Minute); err != nil {
+		t.Errorf("Acquire once the hold passed: %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+// Package lease coordinates machine IDs among snowman replicas. A replica
+// claims a free machine ID from a store shared by the whole fleet and keeps
+// renewing its claim; the store refuses to hand the same ID to another
+// replica while the lease is alive.
+//
+// Lease expiry is judged with the local clocks of the replicas, the TTL must
+// be comfortably larger than the clock skew between them.
+package lease
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrNoFreeID is returned by Acquire when every machine ID is leased.
+	ErrNoFreeID = errors.New("lease: no free machine id")
+
+	// ErrLeaseLost is returned when a lease expired or was taken over by
+	// another holder.
+	ErrLeaseLost = errors.New("lease: lost")
+)
+
+// Lease is a claim of Holder on MachineID, valid until Expires.
+type Lease struct {
+	MachineID int
+	Holder    string
+	Expires   time.Time
+}
+
+// LeaseStore persists the leases of the fleet. Implementations must be safe
+// for concurrent use by several processes.
+type LeaseStore interface {
+	// Acquire claims a machine ID between 0 and maxID that has no live
+	// lease, for ttl. A machine ID whose expired lease was held by the same
+	// holder is preferred. Holders must be unique per process, the live
+	// lease of a holder is never handed out twice.
+	Acquire(ctx context.Context, holder string, maxID int, ttl time.Duration) (Lease, error)
+
+	// Renew extends l for ttl from now. It fails with ErrLeaseLost if l
+	// expired or is now held by someone else.
+	Renew(ctx context.Context, l Lease, ttl time.Duration) (Lease, error)
+
+	// Release gives up l. Its machine ID can be claimed again from
+	// l.Expires on, or right away if that's past: the holder sets it to
+	// the time of the last ID it issued, which may be ahead of the clock.
+	Release(ctx context.Context, l Lease) error
+}
+
+// Keeper holds a lease and renews it on a heartbeat.
+type Keeper struct {
+	store LeaseStore
+	ttl   time.Duration
+	// hold returns until when the machine ID must stay reserved once the
+	// lease is released.
+	hold func() time.Time
+
+	// expires is the expiry of the lease in Unix nanoseconds, or zero once
+	// the lease is lost.
+	expires int64
+
+	mu    sync.Mutex
+	lease Lease
+}
+
+// Acquire claims a machine ID between 0 and maxID from store and returns a
+// Keeper holding it. Call Run to keep the lease alive.
+func Acquire(ctx context.Context, store LeaseStore, holder string, maxID int, ttl time.Duration) (*Keeper, error) {
+	l, err := store.Acquire(ctx, holder, maxID, ttl)
+	if err != nil {
+		return nil, err
+	}
+	k := &Keeper{store: store, ttl: ttl}
+	k.set(l)
+	return k, nil
+}
+
+// HoldUntil makes Run, when it releases the lease, keep the machine ID from
+// being claimed again until the time f returns, e.g. that of the last ID
+// issued under the lease.
+func (k *Keeper) HoldUntil(f func() time.Time) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.hold = f
+}
+
+// Run renews the lease three times per TTL until ctx is done, then releases
+// it, holding the machine ID as set with HoldUntil. It returns ErrLeaseLost
+// as soon as the lease is lost; transient store errors are retried until the
+// lease expires.
+func (k *Keeper) Run(ctx context.Context) error {
+	ticker := time.NewTicker(k.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// stop issuing IDs before reading until when they were
+			// issued.
+			atomic.StoreInt64(&k.expires, 0)
+			l := k.Lease()
+			l.Expires = time.Time{}
+			k.mu.Lock()
+			if k.hold != nil {
+				l.Expires = k.hold()
+			}
+			k.mu.Unlock()
+			// the context is done, release with a fresh one.
+			release, cancel := context.WithTimeout(context.Background(), k.ttl)
+			defer cancel()
+			k.store.Release(release, l)
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		l, err := k.store.Renew(ctx, k.Lease(), k.ttl)
+		switch {
+		case err == nil:
+			k.set(l)
+		case errors.Is(err, ErrLeaseLost) || k.Err() != nil:
+			atomic.StoreInt64(&k.expires, 0)
+			return ErrLeaseLost
+		}
+	}
+}
+
+// Lease returns the lease last acquired or renewed.
+func (k *Keeper) Lease() Lease {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.lease
+}
+
+// Expires returns when the lease expires, or the zero time once it is lost.
+func (k *Keeper) Expires() time.Time {
+	ns := atomic.LoadInt64(&k.expires)
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+// Err returns ErrLeaseLost once the lease expired or was lost, nil while it
+// is alive.
+func (k *Keeper) Err() error {
+	if time.Now().UnixNano() >= atomic.LoadInt64(&k.expires) {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (k *Keeper) set(l Lease) {
+	k.mu.Lock()
+	k.lease = l
+	k.mu.Unlock()
+	atomic.StoreInt64(&k.expires, l.Expires.UnixNano())
+}
@@ -0,0 +1,118 @@
+package lease
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	a, err := s.Acquire(ctx, "a", 1, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := s.Acquire(ctx, "b", 1, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.MachineID != 0 || b.MachineID != 1 {
+		t.Errorf("Acquire leased %d and %d, want 0 and 1", a.MachineID, b.MachineID)
+	}
+	if _, err := s.Acquire(ctx, "c", 1, time.Minute); !errors.Is(err, ErrNoFreeID) {
+		t.Errorf("Acquire with every ID leased: got %v, want ErrNoFreeID", err)
+	}
+	if _, err := s.Acquire(ctx, "a", 1, time.Minute); !errors.Is(err, ErrNoFreeID) {
+		t.Errorf("Acquire by the holder of a live lease: got %v, want ErrNoFreeID", err)
+	}
+
+	renewed, err := s.Renew(ctx, a, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !renewed.Expires.After(a.Expires) {
+		t.Errorf("Renew expires at %v, want after %v", renewed.Expires, a.Expires)
+	}
+	if _, err := s.Renew(ctx, Lease{MachineID: 0, Holder: "c"}, time.Minute); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Renew by another holder: got %v, want ErrLeaseLost", err)
+	}
+
+	// b expires; its holder gets the ID back, once it's free again.
+	time.Sleep(60 * time.Millisecond)
+	if _, err := s.Renew(ctx, b, time.Minute); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Renew of an expired lease: got %v, want ErrLeaseLost", err)
+	}
+	again, err := s.Acquire(ctx, "b", 1, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.MachineID != b.MachineID {
+		t.Errorf("Acquire by the holder of an expired lease = %d, want %d", again.MachineID, b.MachineID)
+	}
+
+	// a release in the past frees the ID right away.
+	again.Expires = time.Time{}
+	if err := s.Release(ctx, again); err != nil {
+		t.Fatal(err)
+	}
+	if l, err := s.Acquire(ctx, "c", 1, time.Minute); err != nil || l.MachineID != 1 {
+		t.Errorf("Acquire after Release = %+v, %v; want machine ID 1", l, err)
+	}
+}
+
+func TestReleaseHold(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+	k, err := Acquire(ctx, s, "a", 0, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	until := time.Now().Add(100 * time.Millisecond)
+	k.HoldUntil(func() time.Time { return until })
+
+	run, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := k.Run(run); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run: %v", err)
+	}
+	if err := k.Err(); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Err after Run returned: got %v, want ErrLeaseLost", err)
+	}
+
+	if _, err := s.Acquire(ctx, "b", 0, time.Minute); !errors.Is(err, ErrNoFreeID) {
+		t.Errorf("Acquire of a held ID: got %v, want ErrNoFreeID", err)
+	}
+	time.Sleep(time.Until(until))
+	if _, err := s.Acquire(ctx, "b", 0, time.Minute); err != nil {
+		t.Errorf("Acquire once the hold passed: %v", err)
+	}
+}
+
+func TestKeeperLost(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+	k, err := Acquire(ctx, s, "a", 0, 30*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := k.Err(); err != nil {
+		t.Fatalf("Err of a new lease: %v", err)
+	}
+
+	// someone else takes the ID over once it expired.
+	time.Sleep(40 * time.Millisecond)
+	if _, err := s.Acquire(ctx, "b", 0, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	run, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := k.Run(run); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Run of a lease taken over: got %v, want ErrLeaseLost", err)
+	}
+	if !k.Expires().IsZero() {
+		t.Errorf("Expires of a lost lease = %v, want the zero time", k.Expires())
+	}
+}
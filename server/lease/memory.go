@@ -0,0 +1,89 @@
+package lease
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a LeaseStore kept in memory. It only coordinates the
+// generators of a single process and is meant for tests.
+type MemoryStore struct {
+	mu     sync.Mutex
+	leases map[int]Lease
+}
+
+var _ LeaseStore = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{leases: make(map[int]Lease)}
+}
+
+// Acquire implements LeaseStore.
+func (s *MemoryStore) Acquire(_ context.Context, holder string, maxID int, ttl time.Duration) (Lease, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	id, ok := pick(func(id int) (Lease, bool) {
+		l, ok := s.leases[id]
+		return l, ok
+	}, holder, maxID, now)
+	if !ok {
+		return Lease{}, ErrNoFreeID
+	}
+	l := Lease{MachineID: id, Holder: holder, Expires: now.Add(ttl)}
+	s.leases[id] = l
+	return l, nil
+}
+
+// Renew implements LeaseStore.
+func (s *MemoryStore) Renew(_ context.Context, l Lease, ttl time.Duration) (Lease, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	cur, ok := s.leases[l.MachineID]
+	if !ok || cur.Holder != l.Holder || !now.Before(cur.Expires) {
+		return Lease{}, ErrLeaseLost
+	}
+	cur.Expires = now.Add(ttl)
+	s.leases[l.MachineID] = cur
+	return cur, nil
+}
+
+// Release implements LeaseStore.
+func (s *MemoryStore) Release(_ context.Context, l Lease) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cur, ok := s.leases[l.MachineID]
+	if !ok || cur.Holder != l.Holder {
+		return nil
+	}
+	if time.Now().Before(l.Expires) {
+		cur.Expires = l.Expires
+		s.leases[l.MachineID] = cur
+		return nil
+	}
+	delete(s.leases, l.MachineID)
+	return nil
+}
+
+// pick returns the machine ID to lease to holder: the one it held last if
+// that lease expired, the lowest free one otherwise. A live lease is never
+// handed out again, even to the same holder.
+func pick(get func(id int) (Lease, bool), holder string, maxID int, now time.Time) (int, bool) {
+	free := -1
+	for id := 0; id <= maxID; id++ {
+		l, ok := get(id)
+		if ok && now.Before(l.Expires) {
+			continue
+		}
+		if ok && l.Holder == holder {
+			return id, true
+		}
+		if free < 0 {
+			free = id
+		}
+	}
+	return free, free >= 0
+}
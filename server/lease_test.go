@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thatique/snowman/server/lease"
+)
+
+func TestLeaseExpired(t *testing.T) {
+	ctx := context.Background()
+	k, err := lease.Acquire(ctx, lease.NewMemoryStore(), "a", 0, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := NewGenerator(0, WithLease(k))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Next(ctx); err != nil {
+		t.Fatalf("Next under a live lease: %v", err)
+	}
+
+	// nothing renews the lease.
+	time.Sleep(60 * time.Millisecond)
+	if _, err := g.Next(ctx); !errors.Is(err, lease.ErrLeaseLost) {
+		t.Errorf("Next: got %v, want ErrLeaseLost", err)
+	}
+	if _, err := g.Reserve(ctx, 10); !errors.Is(err, lease.ErrLeaseLost) {
+		t.Errorf("Reserve: got %v, want ErrLeaseLost", err)
+	}
+	if err := g.Ready(); !errors.Is(err, lease.ErrLeaseLost) {
+		t.Errorf("Ready: got %v, want ErrLeaseLost", err)
+	}
+}
+
+// TestLeaseHandover restarts a replica right after it issued IDs ahead of
+// the clock: the next holder of the machine ID must not issue them again.
+func TestLeaseHandover(t *testing.T) {
+	const drift = 200 * time.Millisecond
+	ctx := context.Background()
+	store := lease.NewMemoryStore()
+
+	k, err := lease.Acquire(ctx, store, "a", 0, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := NewGenerator(0, WithLease(k), WithRollbackPolicy(RollbackDrift, drift))
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, stop := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- k.Run(run) }()
+
+	ranges, err := g.Reserve(ctx, 1<<30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := ranges[len(ranges)-1]
+	max := last.Start + uint64(last.Count) - 1
+	if ahead := g.LogicalTime().Sub(time.Now()); ahead < drift/2 {
+		t.Fatalf("Reserve ran the logical clock %v ahead, want about %v", ahead, drift)
+	}
+
+	stop()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run: %v", err)
+	}
+	if _, err := lease.Acquire(ctx, store, "b", 0, time.Minute); !errors.Is(err, lease.ErrNoFreeID) {
+		t.Fatalf("Acquire before the logical clock of the last holder: got %v, want ErrNoFreeID", err)
+	}
+
+	var next *lease.Keeper
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		next, err = lease.Acquire(ctx, store, "b", 0, time.Minute)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, lease.ErrNoFreeID) || time.Now().After(deadline) {
+			t.Fatalf("Acquire: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	g, err = NewGenerator(0, WithLease(next))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := g.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id <= max {
+		t.Errorf("first ID of the next holder = %d, want more than the last one issued %d", id, max)
+	}
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// MachineIDFromOrdinal derives a machine ID from the ordinal a Kubernetes
+// StatefulSet appends to the hostname of its pods, e.g. 3 for "snowman-3".
+func MachineIDFromOrdinal(hostname string, l Layout) (int, error) {
+	// the hostname may be fully qualified, only the pod name matters.
+	name := strings.SplitN(hostname, ".", 2)[0]
+	i := strings.LastIndexByte(name, '-')
+	if i < 0 {
+		return 0, fmt.Errorf("hostname %q has no StatefulSet ordinal", hostname)
+	}
+	ordinal, err := strconv.Atoi(name[i+1:])
+	if err != nil || ordinal < 0 {
+		return 0, fmt.Errorf("hostname %q has no StatefulSet ordinal", hostname)
+	}
+	if ordinal >= 1<<l.MachineBits {
+		return 0, fmt.Errorf("StatefulSet ordinal %d doesn't fit in %d machine bits", ordinal, l.MachineBits)
+	}
+	return ordinal, nil
+}
+
+// MachineIDFromIP derives a machine ID from the low bits of ip, typically
+// the pod IP. It is unique as long as the pods share a subnet no larger than
+// the machine field.
+func MachineIDFromIP(ip net.IP, l Layout) (int, error) {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	if len(ip) != net.IPv4len && len(ip) != net.IPv6len {
+		return 0, fmt.Errorf("invalid IP address %v", ip)
+	}
+	var low uint64
+	for _, b := range ip[len(ip)-4:] {
+		low = low<<8 | uint64(b)
+	}
+	return int(low & (1<<l.MachineBits - 1)), nil
+}
+
+// MachineIDFromName derives a machine ID from a hash of a stable name, such
+// as the Kubernetes node name. Distinct names may still collide, the odds
+// grow with the number of nodes relative to the size of the machine field.
+func MachineIDFromName(name string, l Layout) int {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int(h.Sum64() & (1<<l.MachineBits - 1))
+}
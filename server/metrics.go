@@ -0,0 +1,145 @@
+package server
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics collects the Prometheus metrics of a Generator and the Server
+// issuing its IDs. Pass it to the generator with WithMetrics and register
+// it with a prometheus.Registerer.
+type Metrics struct {
+	gen *Generator
+
+	idsIssued   *prometheus.CounterVec
+	batchSize   prometheus.Histogram
+	casRetries  prometheus.Counter
+	fallbacks   prometheus.Counter
+	exhausted   prometheus.Counter
+	rollbacks   *prometheus.CounterVec
+	clockOffset *prometheus.Desc
+}
+
+var _ prometheus.Collector = (*Metrics)(nil)
+
+// NewMetrics creates the metrics of a generator.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		idsIssued: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "snowman",
+			Name:      "ids_issued_total",
+			Help:      "Number of IDs issued, by RPC.",
+		}, []string{"rpc"}),
+		batchSize: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "snowman",
+			Name:      "batch_size",
+			Help:      "Number of IDs requested per batch.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 11),
+		}),
+		casRetries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "snowman",
+			Name:      "cas_retries_total",
+			Help:      "Number of failed compare-and-swap attempts on the generator state.",
+		}),
+		fallbacks: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "snowman",
+			Name:      "cas_fallbacks_total",
+			Help:      "Number of IDs issued through the contention fallback after too many failed compare-and-swap attempts.",
+		}),
+		exhausted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "snowman",
+			Name:      "sequence_exhausted_total",
+			Help:      "Number of times the sequence ran out within a millisecond, so the generator waited for the next one or drifted ahead.",
+		}),
+		rollbacks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "snowman",
+			Name:      "clock_rollbacks_total",
+			Help:      "Number of times the wall clock went backwards behind the last issued ID, by policy and outcome of the first ID asked for since: tolerated, blocked or failed.",
+		}, []string{"policy", "outcome"}),
+		clockOffset: prometheus.NewDesc("snowman_clock_offset_seconds",
+			"How far the logical clock is ahead of the wall clock.", nil, nil),
+	}
+}
+
+// WithMetrics makes the generator, and the servers using it, report to m.
+func WithMetrics(m *Metrics) Option {
+	return func(g *Generator) {
+		g.metrics = m
+		m.gen = g
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.idsIssued.Describe(ch)
+	m.batchSize.Describe(ch)
+	m.casRetries.Describe(ch)
+	m.fallbacks.Describe(ch)
+	m.exhausted.Describe(ch)
+	m.rollbacks.Describe(ch)
+	ch <- m.clockOffset
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.idsIssued.Collect(ch)
+	m.batchSize.Collect(ch)
+	m.casRetries.Collect(ch)
+	m.fallbacks.Collect(ch)
+	m.exhausted.Collect(ch)
+	m.rollbacks.Collect(ch)
+	if m.gen != nil {
+		ch <- prometheus.MustNewConstMetric(m.clockOffset, prometheus.GaugeValue, m.gen.clockOffset().Seconds())
+	}
+}
+
+// the methods below are safe to call on a nil *Metrics, so the generator
+// and the server don't have to check whether metrics are enabled.
+
+func (m *Metrics) issued(rpc string, n int) {
+	if m != nil {
+		m.idsIssued.WithLabelValues(rpc).Add(float64(n))
+	}
+}
+
+func (m *Metrics) batch(n int) {
+	if m != nil {
+		m.batchSize.Observe(float64(n))
+	}
+}
+
+func (m *Metrics) casRetry() {
+	if m != nil {
+		m.casRetries.Inc()
+	}
+}
+
+func (m *Metrics) fallback() {
+	if m != nil {
+		m.fallbacks.Inc()
+	}
+}
+
+func (m *Metrics) sequenceExhausted() {
+	if m != nil {
+		m.exhausted.Inc()
+	}
+}
+
+func (m *Metrics) rollback(p RollbackPolicy, outcome string) {
+	if m != nil {
+		m.rollbacks.WithLabelValues(p.String(), outcome).Inc()
+	}
+}
+
+// clockOffset returns how far the logical clock is ahead of the wall clock.
+func (g *Generator) clockOffset() time.Duration {
+	t := g.wallTime()
+	current := atomic.LoadUint64(&g.state) >> g.timeShift & g.timeMask
+	if current <= t {
+		return 0
+	}
+	return time.Duration(current-t) * time.Millisecond
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// Range is a block of Count consecutive IDs, starting at Start.
+type Range struct {
+	Start uint64
+	Count int
+}
+
+// Reserve claims up to n consecutive sequence values in a single atomic
+// step, and returns them as one range per millisecond. The block starts at
+// the current millisecond and spans the following ones as far as the
+// rollback policy lets the logical clock run ahead of the wall clock, and
+// the lease and the last durable high-water mark allow, so it may hold fewer
+// than n IDs; it holds at least one unless an error is returned. Like Next,
+// it gives up waiting once ctx is done.
+func (g *Generator) Reserve(ctx context.Context, n int) ([]Range, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid reservation of %d IDs", n)
+	}
+	if g.lease != nil {
+		if err := g.lease.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// reservations are rare and retrying their compare-and-swap is
+	// unbounded, queue them like the contention fallback of Next.
+	if err := g.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer g.unlock()
+
+	// how far the logical clock may run ahead of the wall clock.
+	var ahead uint64
+	if g.policy == RollbackDrift {
+		ahead = g.maxDrift
+	}
+	perMs := g.sequenceMask + 1
+
+	for {
+		current := atomic.LoadUint64(&g.state)
+		t, wall := g.clock()
+		currentTime := current >> g.timeShift & g.timeMask
+		currentSeq := current & g.sequenceMask
+
+		if retry, err := g.checkRollback(ctx, t, wall, currentTime); err != nil {
+			return nil, err
+		} else if retry {
+			continue
+		}
+
+		// the block starts right after the last issued ID, or at the
+		// beginning of the current millisecond if that's later.
+		startTime, startSeq := t, uint64(0)
+		if t <= currentTime {
+			startTime, startSeq = currentTime, currentSeq+1
+			if currentSeq == g.sequenceMask {
+				startTime, startSeq = currentTime+1, 0
+			}
+		}
+		if startTime > t+ahead {
+			g.metrics.sequenceExhausted()
+			if err := g.waitFor(ctx, startTime-ahead); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		leaseLimit, err := g.checkLease(startTime)
+		if err != nil {
+			return nil, err
+		}
+		persisted, err := g.checkPersisted(startTime)
+		if err != nil {
+			return nil, err
+		}
+
+		// fill the first millisecond, then as many following ones as
+		// allowed, staying before the expiry of the lease and the last
+		// durable high-water mark.
+		maxTime := t + ahead
+		for _, limit := range []uint64{leaseLimit, persisted} {
+			if maxTime >= limit {
+				maxTime = limit - 1
+			}
+		}
+		want := uint64(n)
+		endTime, endSeq := startTime, startSeq+want-1
+		if first := perMs - startSeq; want > first {
+			rest := want - first
+			if limit := (maxTime - startTime) * perMs; rest > limit {
+				rest = limit
+			}
+			endTime, endSeq = startTime, g.sequenceMask
+			if rest > 0 {
+				endTime, endSeq = startTime+(rest-1)/perMs+1, (rest-1)%perMs
+			}
+		}
+
+		if !atomic.CompareAndSwapUint64(&g.state, current, endTime<<g.timeShift|endSeq) {
+			g.metrics.casRetry()
+			continue
+		}
+
+		ranges := make([]Range, 0, endTime-startTime+1)
+		for ms, seq := startTime, startSeq; ms <= endTime; ms, seq = ms+1, 0 {
+			last := g.sequenceMask
+			if ms == endTime {
+				last = endSeq
+			}
+			ranges = append(ranges, Range{
+				Start: ms<<g.timeShift | g.machine | seq,
+				Count: int(last - seq + 1),
+			})
+		}
+		return ranges, nil
+	}
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultMaxDrift is how far the logical clock may run ahead of the wall
+// clock under RollbackDrift when no maximum is configured.
+const DefaultMaxDrift = time.Second
+
+// ErrClockRollback is returned when the wall clock went behind the time of
+// the last issued ID and the rollback policy doesn't allow to carry on.
+var ErrClockRollback = errors.New("clock moved backwards")
+
+// RollbackPolicy decides what a Generator does when the wall clock goes
+// behind the time of the last issued ID, for example after NTP stepped it
+// back.
+type RollbackPolicy int
+
+const (
+	// RollbackDrift keeps issuing IDs from the logical clock as long as it
+	// isn't more than the maximum drift ahead of the wall clock, and fails
+	// with ErrClockRollback afterwards. Running out of sequence numbers
+	// also moves the logical clock ahead and counts towards the drift.
+	RollbackDrift RollbackPolicy = iota
+
+	// RollbackBlock waits for the wall clock to catch up.
+	RollbackBlock
+
+	// RollbackFail fails with ErrClockRollback as soon as the wall clock is
+	// behind.
+	RollbackFail
+)
+
+var rollbackPolicyNames = map[RollbackPolicy]string{
+	RollbackDrift: "drift",
+	RollbackBlock: "block",
+	RollbackFail:  "fail",
+}
+
+// ParseRollbackPolicy parses one of "drift", "block" or "fail".
+func ParseRollbackPolicy(s string) (RollbackPolicy, error) {
+	for p, name := range rollbackPolicyNames {
+		if strings.EqualFold(s, name) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid clock rollback policy %q: must be drift, block or fail", s)
+}
+
+// String returns the name of the policy as accepted by ParseRollbackPolicy.
+func (p RollbackPolicy) String() string {
+	if name, ok := rollbackPolicyNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("RollbackPolicy(%d)", int(p))
+}
+
+// WithRollbackPolicy sets what the generator does when the wall clock goes
+// backwards. maxDrift only applies to RollbackDrift. The default is
+// RollbackDrift with DefaultMaxDrift.
+func WithRollbackPolicy(p RollbackPolicy, maxDrift time.Duration) Option {
+	return func(g *Generator) {
+		g.policy = p
+		g.maxDrift = uint64(maxDrift / time.Millisecond)
+	}
+}
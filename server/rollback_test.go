@@ -0,0 +1,194 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// fakeClock is a wall clock the tests set back and forth.
+type fakeClock struct {
+	ns int64
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{ns: time.Now().UnixNano()}
+}
+
+func (c *fakeClock) Now() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&c.ns))
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	atomic.AddInt64(&c.ns, int64(d))
+}
+
+func newClockGenerator(t *testing.T, c *fakeClock, p RollbackPolicy, opts ...Option) *Generator {
+	t.Helper()
+	opts = append([]Option{WithClock(c.Now), WithRollbackPolicy(p, time.Second)}, opts...)
+	g, err := NewGenerator(1, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestRollbackDrift(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClock()
+	g := newClockGenerator(t, c, RollbackDrift)
+
+	first, err := g.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Add(-500 * time.Millisecond)
+	second, err := g.Next(ctx)
+	if err != nil {
+		t.Fatalf("Next within the maximum drift: %v", err)
+	}
+	if second <= first {
+		t.Errorf("Next after a rollback = %d, want more than %d", second, first)
+	}
+	if err := g.Ready(); err != nil {
+		t.Errorf("Ready within the maximum drift: %v", err)
+	}
+	if _, err := g.Reserve(ctx, 10); err != nil {
+		t.Errorf("Reserve within the maximum drift: %v", err)
+	}
+
+	c.Add(-time.Second)
+	if _, err := g.Next(ctx); !errors.Is(err, ErrClockRollback) {
+		t.Errorf("Next beyond the maximum drift: got %v, want ErrClockRollback", err)
+	}
+	if _, err := g.Reserve(ctx, 10); !errors.Is(err, ErrClockRollback) {
+		t.Errorf("Reserve beyond the maximum drift: got %v, want ErrClockRollback", err)
+	}
+	if err := g.Ready(); !errors.Is(err, ErrClockRollback) {
+		t.Errorf("Ready beyond the maximum drift: got %v, want ErrClockRollback", err)
+	}
+
+	c.Add(1500 * time.Millisecond)
+	third, err := g.Next(ctx)
+	if err != nil {
+		t.Fatalf("Next once the clock caught up: %v", err)
+	}
+	if third <= second {
+		t.Errorf("Next once the clock caught up = %d, want more than %d", third, second)
+	}
+}
+
+func TestRollbackFail(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClock()
+	g := newClockGenerator(t, c, RollbackFail)
+
+	if _, err := g.Next(ctx); err != nil {
+		t.Fatal(err)
+	}
+	c.Add(-time.Millisecond)
+	if _, err := g.Next(ctx); !errors.Is(err, ErrClockRollback) {
+		t.Errorf("Next: got %v, want ErrClockRollback", err)
+	}
+	if _, err := g.Reserve(ctx, 10); !errors.Is(err, ErrClockRollback) {
+		t.Errorf("Reserve: got %v, want ErrClockRollback", err)
+	}
+	if err := g.Ready(); !errors.Is(err, ErrClockRollback) {
+		t.Errorf("Ready: got %v, want ErrClockRollback", err)
+	}
+}
+
+func TestRollbackBlock(t *testing.T) {
+	c := newFakeClock()
+	g := newClockGenerator(t, c, RollbackBlock)
+
+	first, err := g.Next(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Add(-5 * time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := g.Next(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Next: got %v, want DeadlineExceeded", err)
+	}
+	if _, err := g.Reserve(ctx, 10); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Reserve: got %v, want DeadlineExceeded", err)
+	}
+
+	// the servers report the deadline, not an unavailable node.
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := New(g).NextID(ctx, &emptypb.Empty{}); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("NextID: got %v, want DeadlineExceeded", err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		c.Add(5 * time.Minute)
+	}()
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	second, err := g.Next(ctx)
+	if err != nil {
+		t.Fatalf("Next once the clock caught up: %v", err)
+	}
+	if second <= first {
+		t.Errorf("Next once the clock caught up = %d, want more than %d", second, first)
+	}
+	if _, err := g.Reserve(ctx, 10); err != nil {
+		t.Errorf("Reserve once the clock caught up: %v", err)
+	}
+}
+
+func TestRollbackMetric(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClock()
+	m := NewMetrics()
+	g := newClockGenerator(t, c, RollbackDrift, WithMetrics(m))
+	tolerated := m.rollbacks.WithLabelValues("drift", "tolerated")
+	failed := m.rollbacks.WithLabelValues("drift", "failed")
+
+	if _, err := g.Next(ctx); err != nil {
+		t.Fatal(err)
+	}
+	c.Add(-500 * time.Millisecond)
+	for i := 0; i < 10; i++ {
+		if _, err := g.Next(ctx); err != nil {
+			t.Fatal(err)
+		}
+		c.Add(time.Millisecond)
+	}
+	if _, err := g.Reserve(ctx, 10); err != nil {
+		t.Fatal(err)
+	}
+	if n := testutil.ToFloat64(tolerated); n != 1 {
+		t.Errorf("%v tolerated rollbacks counted for one rollback, want 1", n)
+	}
+
+	// once the clock caught up, the next rollback counts again.
+	c.Add(time.Second)
+	if _, err := g.Next(ctx); err != nil {
+		t.Fatal(err)
+	}
+	c.Add(-2 * time.Second)
+	for i := 0; i < 10; i++ {
+		if _, err := g.Next(ctx); !errors.Is(err, ErrClockRollback) {
+			t.Fatalf("Next beyond the maximum drift: got %v, want ErrClockRollback", err)
+		}
+	}
+	if n := testutil.ToFloat64(failed); n != 1 {
+		t.Errorf("%v failed rollbacks counted for one rollback, want 1", n)
+	}
+	if n := testutil.ToFloat64(tolerated); n != 1 {
+		t.Errorf("%v tolerated rollbacks counted, want 1", n)
+	}
+}
@@ -4,41 +4,193 @@ import (
 	"context"
 	"errors"
 
-	"github.com/gogo/protobuf/types"
 	"github.com/thatique/snowman/api/v1"
+	"github.com/thatique/snowman/server/lease"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var _ v1.SnowflakeServiceServer = (*Server)(nil)
 
+// MaxNextIDsLength is the largest batch NextIDs returns in one response,
+// unless the server is created with a smaller WithMaxBatchLength.
+const MaxNextIDsLength = v1.MaxNextIDsLength
+
+// DefaultMaxBatchLength is the largest batch BatchNextID and ReserveRange
+// issue unless the server is created with WithMaxBatchLength.
+const DefaultMaxBatchLength = 100000
+
+// Version of the server reported by GetInfo. It is set at build time with
+// -ldflags "-X github.com/thatique/snowman/server.Version=...".
+var Version = "dev"
+
 type Server struct {
-	gen *Generator
+	v1.UnimplementedSnowflakeServiceServer
+
+	gen      *Generator
+	maxBatch int
+}
+
+// ServerOption configures a Server.
+type ServerOption func(*Server)
+
+// WithMaxBatchLength sets the largest batch BatchNextID and ReserveRange
+// issue, and NextIDs when it is below MaxNextIDsLength; longer requests fail
+// with ResourceExhausted.
+func WithMaxBatchLength(n int) ServerOption {
+	return func(s *Server) {
+		s.maxBatch = n
+	}
+}
+
+// New creates a Server issuing IDs from gen.
+func New(gen *Generator, opts ...ServerOption) *Server {
+	s := &Server{gen: gen, maxBatch: DefaultMaxBatchLength}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-func New(machineID int) *Server {
-	return &Server{gen: NewGenerator(machineID)}
+// Lease returns the machine ID lease the server issues IDs under, if any.
+func (s *Server) Lease() (lease.Lease, bool) {
+	return s.gen.Lease()
 }
 
-func (s *Server) NextID(ctx context.Context, _ *types.Empty) (*v1.Snowflake, error) {
-	id := s.gen.Next()
-	return &v1.Snowflake{ID: v1.ID(id)}, nil
+func (s *Server) NextID(ctx context.Context, _ *emptypb.Empty) (*v1.Snowflake, error) {
+	id, err := s.gen.Next(ctx)
+	if err != nil {
+		return nil, genError(err)
+	}
+	s.gen.metrics.issued("NextID", 1)
+	return v1.NewSnowflake(v1.ID(id)), nil
 }
 
 func (s *Server) BatchNextID(req *v1.BatchIDsRequest, srv v1.SnowflakeService_BatchNextIDServer) error {
 	len := int(req.GetLength())
-	if len <= 0 {
-		return errors.New("length can't be zero or negative")
+	if err := checkLength(len, s.maxBatch); err != nil {
+		return err
 	}
+	s.gen.metrics.batch(len)
 	var (
 		id        uint64
 		snowflake *v1.Snowflake
+		err       error
+		i         int
 	)
-	for i := 0; i < len; i++ {
-		id = s.gen.Next()
-		snowflake = &v1.Snowflake{ID: v1.ID(id)}
-		err := srv.Send(snowflake)
+	defer func() { s.gen.metrics.issued("BatchNextID", i) }()
+	done := srv.Context().Done()
+	for i = 0; i < len; i++ {
+		select {
+		case <-done:
+			return status.FromContextError(srv.Context().Err()).Err()
+		default:
+		}
+		id, err = s.gen.Next(srv.Context())
+		if err != nil {
+			return genError(err)
+		}
+		snowflake = v1.NewSnowflake(v1.ID(id))
+		err = srv.Send(snowflake)
 		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+func (s *Server) NextIDs(ctx context.Context, req *v1.BatchIDsRequest) (*v1.SnowflakeList, error) {
+	len := int(req.GetLength())
+	if err := checkLength(len, min(MaxNextIDsLength, s.maxBatch)); err != nil {
+		return nil, err
+	}
+	s.gen.metrics.batch(len)
+	resp := &v1.SnowflakeList{Ids: make([]uint64, 0, len)}
+	for remaining := len; remaining > 0; {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		ranges, err := s.gen.Reserve(ctx, remaining)
+		if err != nil {
+			return nil, genError(err)
+		}
+		for _, r := range ranges {
+			for i := 0; i < r.Count; i++ {
+				resp.Ids = append(resp.Ids, r.Start+uint64(i))
+			}
+			remaining -= r.Count
+		}
+	}
+	s.gen.metrics.issued("NextIDs", len)
+	return resp, nil
+}
+
+func (s *Server) ReserveRange(ctx context.Context, req *v1.BatchIDsRequest) (*v1.SnowflakeRanges, error) {
+	len := int(req.GetLength())
+	if err := checkLength(len, s.maxBatch); err != nil {
+		return nil, err
+	}
+	s.gen.metrics.batch(len)
+	resp := &v1.SnowflakeRanges{}
+	for reserved := 0; reserved < len; {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		ranges, err := s.gen.Reserve(ctx, len-reserved)
+		if err != nil {
+			return nil, genError(err)
+		}
+		for _, r := range ranges {
+			resp.Ranges = append(resp.Ranges, v1.NewSnowflakeRange(v1.ID(r.Start), r.Count))
+			reserved += r.Count
+		}
+	}
+	s.gen.metrics.issued("ReserveRange", len)
+	return resp, nil
+}
+
+func (s *Server) Decode(ctx context.Context, req *v1.Snowflake) (*v1.SnowflakeParts, error) {
+	id, err := req.ID()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return v1.NewSnowflakeParts(id.Parts(s.gen.Layout(), s.gen.Epoch())), nil
+}
+
+func (s *Server) GetInfo(ctx context.Context, _ *emptypb.Empty) (*v1.ServerInfo, error) {
+	info := &v1.ServerInfo{
+		Layout:            s.gen.Layout().Proto(),
+		Epoch:             timestamppb.New(s.gen.Epoch()),
+		MachineId:         int32(s.gen.MachineID()),
+		Version:           Version,
+		LogicalTime:       timestamppb.New(s.gen.LogicalTime()),
+		SequenceRemaining: int64(s.gen.SequenceRemaining()),
+	}
+	if l, ok := s.gen.Lease(); ok {
+		info.LeaseExpires = timestamppb.New(l.Expires)
+	}
+	return info, nil
+}
+
+// genError converts an error of the generator to a gRPC status: the
+// request ran out of time waiting for the clock, or the node can't issue
+// IDs right now and the client should try another one.
+func genError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+// checkLength validates the length of a batch request against max.
+func checkLength(length, max int) error {
+	if length <= 0 {
+		return status.Error(codes.InvalidArgument, "length can't be zero or negative")
+	}
+	if length > max {
+		return status.Errorf(codes.ResourceExhausted, "length can't be more than %d", max)
+	}
+	return nil
+}
@@ -1,53 +1,283 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"sync/atomic"
 	"time"
-)
 
-const (
-	epoch        = 1491696000000
-	serverBits   = 10
-	sequenceBits = 12
-	timeBits     = 42
-	serverShift  = sequenceBits
-	timeShift    = sequenceBits + serverBits
-	serverMax    = ^(-1 << serverBits)
-	sequenceMask = ^(-1 << sequenceBits)
-	timeMask     = ^(-1 << timeBits)
+	"github.com/thatique/snowman/server/lease"
 )
 
+// Generator issues unique, roughly time ordered 64 bit IDs for a single
+// machine.
 type Generator struct {
 	state   uint64
 	machine uint64
+	epoch   uint64
+
+	layout       Layout
+	timeShift    uint
+	timeMask     uint64
+	sequenceMask uint64
+
+	policy   RollbackPolicy
+	maxDrift uint64
+	// wall is the latest reading of the wall clock, counted from the epoch.
+	wall uint64
+	// rolledBack is the reading the wall clock last went backwards from.
+	rolledBack uint64
+
+	highWater time.Time
+	maxWait   time.Duration
+	// persisted is the time, counted from the epoch, of the high-water mark
+	// last made durable; IDs are only issued before it. It is all ones when
+	// the state isn't persisted.
+	persisted uint64
+
+	lease   *lease.Keeper
+	metrics *Metrics
+	now     func() time.Time
+
+	// sem serializes the callers that failed too many compare-and-swaps,
+	// and reservations. it is a channel rather than a mutex so that
+	// waiting for it can be cancelled.
+	sem chan struct{}
+}
+
+// casAttempts is how many compare-and-swaps Next tries before it falls back
+// to queueing on the lock of the generator.
+var casAttempts = 100
+
+// Option configures a Generator.
+type Option func(*Generator)
+
+// WithLayout sets the bit layout of generated IDs. The default is
+// DefaultLayout.
+func WithLayout(l Layout) Option {
+	return func(g *Generator) {
+		g.layout = l
+	}
+}
+
+// WithEpoch sets the instant the time field of IDs counts from. The default
+// is DefaultEpoch.
+func WithEpoch(epoch time.Time) Option {
+	return func(g *Generator) {
+		g.epoch = uint64(epoch.UnixNano() / 1e6)
+	}
+}
+
+// WithLease makes the generator refuse to issue IDs once k lost its lease,
+// and IDs stamped at or after the expiry of the lease even while the logical
+// clock runs ahead of the wall clock: the next holder of the machine ID may
+// issue IDs from then on. The leased machine ID must match the machine field
+// of the generator.
+func WithLease(k *lease.Keeper) Option {
+	return func(g *Generator) {
+		g.lease = k
+	}
+}
+
+// WithClock makes the generator read the wall clock from now instead of
+// time.Now, e.g. to test how it copes with a clock going backwards.
+func WithClock(now func() time.Time) Option {
+	return func(g *Generator) {
+		g.now = now
+	}
 }
 
-func NewGenerator(machineID int) *Generator {
-	if machineID < 0 || machineID > serverMax {
-		panic(fmt.Errorf("invalid machine id; must be 0 ≤ id < %d", serverMax))
+// NewGenerator creates a generator for the given machine ID. The machine ID
+// spans both the datacenter and the machine fields of the layout, see
+// Layout.NodeID.
+func NewGenerator(machineID int, opts ...Option) (*Generator, error) {
+	g := &Generator{
+		layout:    DefaultLayout,
+		persisted: ^uint64(0),
+		now:       time.Now,
+		sem:       make(chan struct{}, 1),
+	}
+	WithEpoch(DefaultEpoch)(g)
+	WithRollbackPolicy(RollbackDrift, DefaultMaxDrift)(g)
+	for _, opt := range opts {
+		opt(g)
+	}
+	if err := g.layout.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateEpoch(g.Epoch(), g.layout, g.now()); err != nil {
+		return nil, err
+	}
+	if _, ok := rollbackPolicyNames[g.policy]; !ok {
+		return nil, fmt.Errorf("invalid clock rollback policy %v", g.policy)
 	}
-	return &Generator{
-		state:   0,
-		machine: uint64(machineID << serverShift),
+	if machineID < 0 || machineID > g.layout.MaxMachineID() {
+		return nil, fmt.Errorf("invalid machine id; must be 0 ≤ id ≤ %d", g.layout.MaxMachineID())
 	}
+	if g.lease != nil && g.lease.Lease().MachineID != machineID&(1<<g.layout.MachineBits-1) {
+		return nil, fmt.Errorf("machine id %d doesn't match the leased machine id %d", machineID, g.lease.Lease().MachineID)
+	}
+	g.timeShift = g.layout.NodeBits() + g.layout.SequenceBits
+	g.timeMask = 1<<g.layout.TimeBits - 1
+	g.sequenceMask = 1<<g.layout.SequenceBits - 1
+	g.machine = uint64(machineID) << g.layout.SequenceBits
+	if err := g.waitHighWater(); err != nil {
+		return nil, err
+	}
+	if g.lease != nil {
+		// keep the machine ID from the next holder until after the IDs
+		// issued ahead of the clock, and the ones still in flight: they
+		// are at most a millisecond past the logical time.
+		g.lease.HoldUntil(func() time.Time {
+			return g.LogicalTime().Add(2 * time.Millisecond)
+		})
+	}
+	return g, nil
+}
+
+// Layout returns the bit layout of the IDs issued by this generator.
+func (g *Generator) Layout() Layout {
+	return g.layout
+}
+
+// Epoch returns the instant the time field of issued IDs counts from.
+func (g *Generator) Epoch() time.Time {
+	return time.Unix(0, int64(g.epoch)*int64(time.Millisecond)).UTC()
+}
+
+// Lease returns the machine ID lease of the generator, if it has one.
+func (g *Generator) Lease() (lease.Lease, bool) {
+	if g.lease == nil {
+		return lease.Lease{}, false
+	}
+	return g.lease.Lease(), true
 }
 
+// MachineID returns the machine ID embedded in every issued ID.
 func (g *Generator) MachineID() int {
-	return int(g.machine >> serverShift)
+	return int(g.machine >> g.layout.SequenceBits)
+}
+
+// LogicalTime returns the later of the wall clock and the time of the last
+// issued ID.
+func (g *Generator) LogicalTime() time.Time {
+	t := atomic.LoadUint64(&g.state) >> g.timeShift & g.timeMask
+	if wall := g.wallTime(); wall > t {
+		t = wall
+	}
+	return time.Unix(0, int64(g.epoch+t)*int64(time.Millisecond)).UTC()
+}
+
+// SequenceRemaining returns how many IDs can still be issued in the current
+// millisecond without waiting or drifting ahead of the wall clock.
+func (g *Generator) SequenceRemaining() int {
+	current := atomic.LoadUint64(&g.state)
+	if wall := g.wallTime(); wall > current>>g.timeShift&g.timeMask {
+		return int(g.sequenceMask) + 1
+	}
+	return int(g.sequenceMask - current&g.sequenceMask)
+}
+
+// Ready returns nil when the generator can issue IDs right away, otherwise
+// the reason it can't: a lost lease, a wall clock still behind the persisted
+// high-water mark or past the last one made durable, or a wall clock further
+// behind the last issued ID than the rollback policy tolerates.
+func (g *Generator) Ready() error {
+	if g.lease != nil {
+		if err := g.lease.Err(); err != nil {
+			return err
+		}
+	}
+	if behind := g.highWater.Sub(g.now()); behind > 0 {
+		return fmt.Errorf("clock is %s behind the persisted high-water mark", behind)
+	}
+	t := g.wallTime()
+	if _, err := g.checkPersisted(t); err != nil {
+		return err
+	}
+	current := atomic.LoadUint64(&g.state) >> g.timeShift & g.timeMask
+	tolerated := uint64(0)
+	if g.policy == RollbackDrift {
+		tolerated = g.maxDrift
+	}
+	if t < current && current-t > tolerated {
+		return fmt.Errorf("%w: wall clock is %dms behind the last issued ID", ErrClockRollback, current-t)
+	}
+	return nil
 }
 
-func (g *Generator) Next() uint64 {
+// Next returns the next ID, or an error wrapping ErrClockRollback when the
+// wall clock is behind and the rollback policy forbids to go on. It fails
+// with lease.ErrLeaseLost once the machine ID lease is lost, and with
+// ErrNotPersisted when the ID would pass the last durable high-water mark.
+// When it has to wait, for the wall clock to catch up or for other callers,
+// it gives up with the error of ctx once ctx is done.
+func (g *Generator) Next(ctx context.Context) (uint64, error) {
+	if g.lease != nil {
+		if err := g.lease.Err(); err != nil {
+			return 0, err
+		}
+	}
+
+	// we attempt casAttempts times to update the millisecond part of the
+	// state and increment the sequence atomically. each attempt is approx
+	// ~30ns so we spend around ~3µs total.
+	if id, ok, err := g.next(ctx, casAttempts); ok || err != nil {
+		return id, err
+	}
+	return g.fallback(ctx)
+}
+
+// fallback issues an ID once the lock-free attempts failed. since we failed
+// that many times, there's high contention. the callers that lost that many
+// races queue on the lock, so only one of them at a time keeps competing
+// with the lock-free callers, which give up after as many attempts
+// themselves; it eventually wins. each attempt computes a valid successor of
+// the state it read, so unlike blindly adding one to the state, the sequence
+// never rolls over into the machine id.
+func (g *Generator) fallback(ctx context.Context) (uint64, error) {
+	g.metrics.fallback()
+	if err := g.lock(ctx); err != nil {
+		return 0, err
+	}
+	defer g.unlock()
+	id, _, err := g.next(ctx, -1)
+	return id, err
+}
+
+// lock takes the lock of the generator, unless ctx is done first.
+func (g *Generator) lock(ctx context.Context) error {
+	select {
+	case g.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (g *Generator) unlock() {
+	<-g.sem
+}
+
+// next tries up to maxAttempts compare-and-swaps of the state, or until one
+// succeeds when maxAttempts is negative. It reports whether it issued an
+// ID. waiting for the clock doesn't count as an attempt.
+func (g *Generator) next(ctx context.Context, maxAttempts int) (uint64, bool, error) {
 	var state uint64
 
-	// we attempt 100 times to update the millisecond part of the state
-	// and increment the sequence atomically. each attempt is approx ~30ns
-	// so we spend around ~3µs total.
-	for i := 0; i < 100; i++ {
-		t := (now() - epoch) & timeMask
+	for attempts := 0; maxAttempts < 0 || attempts < maxAttempts; {
+		// read the clock after the state, so that it's never older than
+		// the time the state was computed from.
 		current := atomic.LoadUint64(&g.state)
-		currentTime := current >> timeShift & timeMask
-		currentSeq := current & sequenceMask
+		t, wall := g.clock()
+		currentTime := current >> g.timeShift & g.timeMask
+		currentSeq := current & g.sequenceMask
+
+		if retry, err := g.checkRollback(ctx, t, wall, currentTime); err != nil {
+			return 0, false, err
+		} else if retry {
+			continue
+		}
 
 		// this sequence of conditionals ensures a monotonically increasing
 		// state.
@@ -55,37 +285,163 @@ func (g *Generator) Next() uint64 {
 		switch {
 		// if our time is in the future, use that with a zero sequence number.
 		case t > currentTime:
-			state = t << timeShift
+			state = t << g.timeShift
 
 		// we now know that our time is at or before the current time.
 		// if we're at the maximum sequence, bump to the next millisecond
-		case currentSeq == sequenceMask:
-			state = (currentTime + 1) << timeShift
+		// when drifting there is allowed, otherwise wait for it. drifting
+		// is capped at the maximum drift so that sustained load doesn't
+		// pass for a clock rollback.
+		case currentSeq == g.sequenceMask:
+			g.metrics.sequenceExhausted()
+			if g.policy != RollbackDrift || currentTime+1-t > g.maxDrift {
+				if err := g.waitFor(ctx, currentTime+1); err != nil {
+					return 0, false, err
+				}
+				continue
+			}
+			state = (currentTime + 1) << g.timeShift
 
 		// otherwise, increment the sequence.
 		default:
 			state = current + 1
 		}
 
+		if _, err := g.checkLease(state >> g.timeShift & g.timeMask); err != nil {
+			return 0, false, err
+		}
+		if _, err := g.checkPersisted(state >> g.timeShift & g.timeMask); err != nil {
+			return 0, false, err
+		}
+
 		if atomic.CompareAndSwapUint64(&g.state, current, state) {
-			break
+			return state | g.machine, true, nil
 		}
+		g.metrics.casRetry()
+		attempts++
+	}
+	return 0, false, nil
+}
 
-		state = 0
+// clock reads the wall clock, counted from the epoch, and returns it with
+// the latest earlier reading: the clock went backwards if it is behind. The
+// logical clock also runs ahead of the wall clock when the sequence ran out
+// or a reservation drifted, that alone isn't a rollback.
+func (g *Generator) clock() (t, wall uint64) {
+	// load the latest reading before taking ours, so that a later reading
+	// of another goroutine doesn't pass for a rollback.
+	wall = atomic.LoadUint64(&g.wall)
+	t = g.wallTime()
+	for t > wall && !atomic.CompareAndSwapUint64(&g.wall, wall, t) {
+		wall = atomic.LoadUint64(&g.wall)
 	}
+	return t, wall
+}
 
-	// since we failed 100 times, there's high contention. bail out of the
-	// loop to bound the time we'll spend in this method, and just add
-	// one to the counter. this can cause millisecond drift, but hopefully
-	// some CAS eventually succeeds and fixes the milliseconds. additionally,
-	// if the sequence is already at the maximum, adding 1 here can cause
-	// it to roll over into the machine id. giving the CAS 100 attempts
-	// helps to avoid these problems.
-	if state == 0 {
-		state = atomic.AddUint64(&g.state, 1)
+// newRollback reports whether the wall clock going backwards from the
+// reading wall wasn't counted yet: a rollback is counted once, however many
+// IDs are asked for until the clock catches up again.
+func (g *Generator) newRollback(wall uint64) bool {
+	for {
+		last := atomic.LoadUint64(&g.rolledBack)
+		if wall <= last {
+			return false
+		}
+		if atomic.CompareAndSwapUint64(&g.rolledBack, last, wall) {
+			return true
+		}
 	}
+}
+
+// checkRollback lets the rollback policy decide whether we may keep counting
+// on the logical clock when the wall clock t is behind currentTime, the time
+// of the last issued ID. The wall clock went backwards if t is behind wall,
+// its latest earlier reading; each rollback is counted once, by the outcome
+// of its first check. It reports whether it waited for the clock, in which
+// case the caller must read the state again; the wait ends early with the
+// error of ctx.
+func (g *Generator) checkRollback(ctx context.Context, t, wall, currentTime uint64) (bool, error) {
+	if t >= currentTime {
+		return false, nil
+	}
+	rollback := t < wall && g.newRollback(wall)
+	drift := currentTime - t
+	switch {
+	case g.policy == RollbackBlock:
+		if rollback {
+			g.metrics.rollback(g.policy, "blocked")
+		}
+		if err := g.waitFor(ctx, currentTime); err != nil {
+			return false, err
+		}
+		return true, nil
+	case g.policy == RollbackFail, drift > g.maxDrift:
+		if rollback {
+			g.metrics.rollback(g.policy, "failed")
+		}
+		return false, fmt.Errorf("%w: wall clock is %dms behind the last issued ID", ErrClockRollback, drift)
+	}
+	if rollback {
+		g.metrics.rollback(g.policy, "tolerated")
+	}
+	return false, nil
+}
 
-	return state | g.machine
+// leaseLimit returns the time, counted from the epoch, the lease expires
+// at: IDs must be stamped before it. It is all ones without a lease.
+func (g *Generator) leaseLimit() uint64 {
+	if g.lease == nil {
+		return ^uint64(0)
+	}
+	expires := g.lease.Expires()
+	if expires.IsZero() {
+		return 0
+	}
+	ms := uint64(expires.UnixNano() / 1e6)
+	if ms <= g.epoch {
+		return 0
+	}
+	return ms - g.epoch
 }
 
-func now() uint64 { return uint64(time.Now().UnixNano() / 1e6) }
+// checkLease fails when an ID at ms, counted from the epoch, would be
+// stamped at or after the expiry of the lease. It returns the expiry it
+// checked against otherwise.
+func (g *Generator) checkLease(ms uint64) (uint64, error) {
+	limit := g.leaseLimit()
+	if ms >= limit {
+		return 0, fmt.Errorf("%w: ID at %dms would outlive the lease expiring at %dms", lease.ErrLeaseLost, ms, limit)
+	}
+	return limit, nil
+}
+
+// waitFor blocks until the wall clock reaches ms, counted from the epoch, or
+// until ctx is done. It reads the clock at least every maxWaitStep, so that
+// a clock stepped forward ends the wait early.
+func (g *Generator) waitFor(ctx context.Context, ms uint64) error {
+	for {
+		t := g.wallTime()
+		if t >= ms {
+			return nil
+		}
+		d := time.Duration(ms-t) * time.Millisecond
+		if d > maxWaitStep {
+			d = maxWaitStep
+		}
+		timer := time.NewTimer(d)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("waiting for the clock: %w", ctx.Err())
+		}
+	}
+}
+
+// maxWaitStep is the longest waitFor sleeps without reading the clock.
+const maxWaitStep = 10 * time.Millisecond
+
+// wallTime returns the wall clock, counted from the epoch.
+func (g *Generator) wallTime() uint64 {
+	return (uint64(g.now().UnixNano()/1e6) - g.epoch) & g.timeMask
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/thatique/snowman/api/v1"
+)
+
+// TestGeneratorContention issues IDs from many goroutines at once, through
+// Next, Reserve and the contention fallback, with a single
+// compare-and-swap attempt so that lost races fall back too. No ID may be
+// issued twice and the sequence must never spill into the node fields.
+func TestGeneratorContention(t *testing.T) {
+	defer func(n int) { casAttempts = n }(casAttempts)
+	casAttempts = 1
+
+	layouts := map[string]Layout{
+		"default":   DefaultLayout,
+		"twitter":   TwitterLayout,
+		"sonyflake": SonyflakeLayout,
+	}
+	for name, layout := range layouts {
+		layout := layout
+		t.Run(name, func(t *testing.T) {
+			testGeneratorContention(t, layout)
+		})
+	}
+}
+
+func testGeneratorContention(t *testing.T, layout Layout) {
+	const (
+		perWorker   = 3000
+		reserveEach = 10
+		reserveLen  = 300
+	)
+	workers := 4 * runtime.GOMAXPROCS(0)
+	if workers < 16 {
+		workers = 16
+	}
+
+	// all node bits set, so that a sequence carrying into them shows.
+	machineID := layout.MaxMachineID()
+	metrics := NewMetrics()
+	g, err := NewGenerator(machineID, WithLayout(layout), WithMetrics(metrics))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	issued := make([][]uint64, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			ids := make([]uint64, 0, perWorker*reserveLen/reserveEach)
+			for i := 0; i < perWorker; i++ {
+				if i%reserveEach == 0 {
+					ranges, err := g.Reserve(ctx, 1+(w*perWorker+i)%reserveLen)
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					for _, r := range ranges {
+						for j := 0; j < r.Count; j++ {
+							ids = append(ids, r.Start+uint64(j))
+						}
+					}
+					continue
+				}
+				// half the workers take the fallback every time, as
+				// Next does after losing casAttempts races, which
+				// a single CPU seldom makes them lose.
+				next := g.Next
+				if w%2 == 1 {
+					next = g.fallback
+				}
+				id, err := next(ctx)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				ids = append(ids, id)
+			}
+			issued[w] = ids
+		}(w)
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+
+	if n := testutil.ToFloat64(metrics.fallbacks); n == 0 {
+		t.Error("no ID was issued through the contention fallback")
+	}
+
+	wantDatacenter := machineID >> layout.MachineBits
+	wantMachine := machineID & (1<<layout.MachineBits - 1)
+	seen := make(map[uint64]bool)
+	for _, ids := range issued {
+		for _, id := range ids {
+			if seen[id] {
+				t.Fatalf("ID %d issued twice", id)
+			}
+			seen[id] = true
+			p := v1.ID(id).Parts(layout, g.Epoch())
+			if p.Datacenter != wantDatacenter || p.Machine != wantMachine {
+				t.Fatalf("ID %d has datacenter %d and machine %d, want %d and %d",
+					id, p.Datacenter, p.Machine, wantDatacenter, wantMachine)
+			}
+		}
+	}
+	t.Logf("%d unique IDs from %d workers", len(seen), workers)
+}
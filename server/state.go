@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ErrNotPersisted is returned when issuing an ID would pass the high-water
+// mark last written to the state file, for example because writing it
+// stalled.
+var ErrNotPersisted = errors.New("high-water mark not persisted")
+
+// ReadStateFile returns the high-water mark stored in the state file at
+// path. A missing file isn't an error and yields the zero time.
+func ReadStateFile(path string) (time.Time, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	ms, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid state file %s: %v", path, err)
+	}
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC(), nil
+}
+
+// WriteStateFile durably replaces the state file at path with the high-water
+// mark hw. The mark is written to a temporary file which is synced and then
+// renamed over path, so a crash never leaves a truncated state file behind.
+func WriteStateFile(path string, hw time.Time) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	ms := hw.UnixNano() / int64(time.Millisecond)
+	if _, err = f.WriteString(strconv.FormatInt(ms, 10) + "\n"); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+// WithHighWater makes NewGenerator wait until the wall clock passed hw, the
+// high-water mark persisted by a previous run. If the clock is more than
+// maxWait behind, NewGenerator refuses to start instead. The generator then
+// only issues IDs covered by a mark PersistState made durable, so it must be
+// run as well.
+func WithHighWater(hw time.Time, maxWait time.Duration) Option {
+	return func(g *Generator) {
+		g.highWater = hw
+		g.maxWait = maxWait
+		g.persisted = 0
+	}
+}
+
+// waitHighWater blocks until the wall clock passed the configured high-water
+// mark and makes sure the logical clock starts from there.
+func (g *Generator) waitHighWater() error {
+	if g.highWater.IsZero() {
+		return nil
+	}
+	hw := uint64(g.highWater.UnixNano() / 1e6)
+	if hw <= g.epoch {
+		return nil
+	}
+	if behind := g.highWater.Sub(g.now()); behind > 0 {
+		if behind > g.maxWait {
+			return fmt.Errorf("clock is %s behind the persisted high-water mark %s",
+				behind, g.highWater.Format(time.RFC3339Nano))
+		}
+		if err := g.waitFor(context.Background(), (hw-g.epoch)&g.timeMask); err != nil {
+			return err
+		}
+	}
+	atomic.StoreUint64(&g.state, ((hw-g.epoch)&g.timeMask)<<g.timeShift)
+	return nil
+}
+
+// PersistState writes a high-water mark for g to the state file at path
+// every interval, until ctx is done or a write fails. The mark is set two
+// intervals plus the maximum drift ahead of the last issued ID, so that it
+// still covers the IDs issued between two writes. Once a mark is durable,
+// g issues no ID past it: if a write stalls, g stops rather than issue IDs
+// a restart after a crash could issue again.
+func (g *Generator) PersistState(ctx context.Context, path string, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	margin := 2 * interval
+	if g.policy == RollbackDrift {
+		margin += time.Duration(g.maxDrift) * time.Millisecond
+	}
+	for {
+		hw := g.LogicalTime().Add(margin)
+		if err := WriteStateFile(path, hw); err != nil {
+			return fmt.Errorf("persisting state: %v", err)
+		}
+		atomic.StoreUint64(&g.persisted, (uint64(hw.UnixNano()/1e6)-g.epoch)&g.timeMask)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkPersisted fails when an ID at ms, counted from the epoch, would pass
+// the last durable high-water mark. It returns the mark it checked against
+// otherwise.
+func (g *Generator) checkPersisted(ms uint64) (uint64, error) {
+	persisted := atomic.LoadUint64(&g.persisted)
+	if ms >= persisted {
+		return 0, fmt.Errorf("%w: ID at %dms would pass the mark at %dms", ErrNotPersisted, ms, persisted)
+	}
+	return persisted, nil
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thatique/snowman/api/v1"
+)
+
+func TestStateFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state")
+
+	hw, err := ReadStateFile(path)
+	if err != nil || !hw.IsZero() {
+		t.Errorf("ReadStateFile of a missing file = %v, %v; want the zero time", hw, err)
+	}
+
+	want := time.Date(2026, 10, 18, 12, 0, 0, 123456789, time.UTC)
+	if err := WriteStateFile(path, want); err != nil {
+		t.Fatal(err)
+	}
+	hw, err = ReadStateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hw.Equal(want.Truncate(time.Millisecond)) {
+		t.Errorf("ReadStateFile = %v, want %v", hw, want.Truncate(time.Millisecond))
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("WriteStateFile left its temporary file behind: %v", err)
+	}
+
+	for _, data := range []string{"", "garbage\n", "12.5\n"} {
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ReadStateFile(path); err == nil {
+			t.Errorf("ReadStateFile of %q succeeded, want an error", data)
+		}
+	}
+}
+
+func TestHighWaterWait(t *testing.T) {
+	hw := time.Now().Add(100 * time.Millisecond)
+	g, err := NewGenerator(1, WithHighWater(hw, time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the generator waits for the millisecond of the mark.
+	hw = hw.Truncate(time.Millisecond)
+	if now := time.Now(); now.Before(hw) {
+		t.Errorf("NewGenerator returned at %v, before the high-water mark %v", now, hw)
+	}
+	if lt := g.LogicalTime(); lt.Before(hw) {
+		t.Errorf("LogicalTime = %v, want at least the high-water mark %v", lt, hw)
+	}
+}
+
+func TestHighWaterTooFarAhead(t *testing.T) {
+	hw := time.Now().Add(time.Minute)
+	if _, err := NewGenerator(1, WithHighWater(hw, time.Second)); err == nil {
+		t.Error("NewGenerator started a minute behind the high-water mark, want an error")
+	}
+}
+
+func TestNotPersisted(t *testing.T) {
+	const interval = 50 * time.Millisecond
+	path := filepath.Join(t.TempDir(), "state")
+	c := newFakeClock()
+	g := newClockGenerator(t, c, RollbackDrift, WithHighWater(time.Time{}, 0))
+
+	bg := context.Background()
+	if _, err := g.Next(bg); !errors.Is(err, ErrNotPersisted) {
+		t.Errorf("Next before any mark was written: got %v, want ErrNotPersisted", err)
+	}
+
+	ctx, cancel := context.WithCancel(bg)
+	done := make(chan error, 1)
+	go func() { done <- g.PersistState(ctx, path, interval) }()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, err := g.Next(bg)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ErrNotPersisted) || time.Now().After(deadline) {
+			t.Fatalf("Next once PersistState runs: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("PersistState: %v", err)
+	}
+
+	hw, err := ReadStateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the mark covers two intervals and the maximum drift.
+	if min := c.Now().Add(2*interval + time.Second - time.Millisecond); hw.Before(min) {
+		t.Errorf("persisted mark %v, want at least %v", hw, min)
+	}
+
+	// reservations stop right before the mark.
+	c.Add(hw.Sub(c.Now()) - 2*time.Millisecond)
+	ranges, err := g.Reserve(bg, 1<<30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := ranges[len(ranges)-1]
+	if p := v1.ID(last.Start).Parts(g.Layout(), g.Epoch()); !p.Time.Before(hw) {
+		t.Errorf("Reserve issued IDs at %v, want before the mark %v", p.Time, hw)
+	}
+
+	c.Add(2 * time.Millisecond)
+	if _, err := g.Next(bg); !errors.Is(err, ErrNotPersisted) {
+		t.Errorf("Next at the mark: got %v, want ErrNotPersisted", err)
+	}
+	if _, err := g.Reserve(bg, 1); !errors.Is(err, ErrNotPersisted) {
+		t.Errorf("Reserve at the mark: got %v, want ErrNotPersisted", err)
+	}
+	if err := g.Ready(); !errors.Is(err, ErrNotPersisted) {
+		t.Errorf("Ready at the mark: got %v, want ErrNotPersisted", err)
+	}
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+
+	"github.com/thatique/snowman/api/v1"
+	"github.com/thatique/snowman/api/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// V2 returns the v2 API of s. It issues IDs from the same generator as the
+// v1 API, and only differs in how IDs are encoded on the wire.
+func (s *Server) V2() v2.SnowflakeServiceServer {
+	return &serverV2{s: s}
+}
+
+type serverV2 struct {
+	v2.UnimplementedSnowflakeServiceServer
+
+	s *Server
+}
+
+func (s *serverV2) NextID(ctx context.Context, req *emptypb.Empty) (*v2.Snowflake, error) {
+	snowflake, err := s.s.NextID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return toV2(snowflake)
+}
+
+func (s *serverV2) BatchNextID(req *v2.BatchIDsRequest, srv v2.SnowflakeService_BatchNextIDServer) error {
+	return s.s.BatchNextID(&v1.BatchIDsRequest{Length: req.GetLength()}, batchNextIDServerV2{srv})
+}
+
+// batchNextIDServerV2 sends the IDs of the v1 BatchNextID to a v2 stream.
+type batchNextIDServerV2 struct {
+	v2.SnowflakeService_BatchNextIDServer
+}
+
+func (srv batchNextIDServerV2) Send(snowflake *v1.Snowflake) error {
+	resp, err := toV2(snowflake)
+	if err != nil {
+		return err
+	}
+	return srv.SnowflakeService_BatchNextIDServer.Send(resp)
+}
+
+// toV2 converts a snowflake issued by the v1 API.
+func toV2(snowflake *v1.Snowflake) (*v2.Snowflake, error) {
+	id, err := snowflake.ID()
+	if err != nil {
+		return nil, err
+	}
+	return &v2.Snowflake{Id: uint64(id)}, nil
+}
+
+func (s *serverV2) NextIDs(ctx context.Context, req *v2.BatchIDsRequest) (*v2.SnowflakeList, error) {
+	list, err := s.s.NextIDs(ctx, &v1.BatchIDsRequest{Length: req.GetLength()})
+	if err != nil {
+		return nil, err
+	}
+	return &v2.SnowflakeList{Ids: list.GetIds()}, nil
+}
+
+func (s *serverV2) ReserveRange(ctx context.Context, req *v2.BatchIDsRequest) (*v2.SnowflakeRanges, error) {
+	ranges, err := s.s.ReserveRange(ctx, &v1.BatchIDsRequest{Length: req.GetLength()})
+	if err != nil {
+		return nil, err
+	}
+	resp := &v2.SnowflakeRanges{Ranges: make([]*v2.SnowflakeRange, len(ranges.GetRanges()))}
+	for i, r := range ranges.GetRanges() {
+		start, err := r.StartID()
+		if err != nil {
+			return nil, err
+		}
+		resp.Ranges[i] = &v2.SnowflakeRange{Start: uint64(start), Count: r.GetCount()}
+	}
+	return resp, nil
+}
+
+func (s *serverV2) Decode(ctx context.Context, req *v2.Snowflake) (*v2.SnowflakeParts, error) {
+	parts, err := s.s.Decode(ctx, v1.NewSnowflake(v1.ID(req.GetId())))
+	if err != nil {
+		return nil, err
+	}
+	return &v2.SnowflakeParts{
+		Time:         parts.GetTime(),
+		DatacenterId: parts.GetDatacenterId(),
+		MachineId:    parts.GetMachineId(),
+		Sequence:     parts.GetSequence(),
+	}, nil
+}
+
+func (s *serverV2) GetInfo(ctx context.Context, req *emptypb.Empty) (*v2.ServerInfo, error) {
+	info, err := s.s.GetInfo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &v2.ServerInfo{
+		Layout: &v2.IDLayout{
+			TimeBits:       info.GetLayout().GetTimeBits(),
+			DatacenterBits: info.GetLayout().GetDatacenterBits(),
+			MachineBits:    info.GetLayout().GetMachineBits(),
+			SequenceBits:   info.GetLayout().GetSequenceBits(),
+		},
+		Epoch:             info.GetEpoch(),
+		MachineId:         info.GetMachineId(),
+		Version:           info.GetVersion(),
+		LogicalTime:       info.GetLogicalTime(),
+		SequenceRemaining: info.GetSequenceRemaining(),
+		LeaseExpires:      info.GetLeaseExpires(),
+	}, nil
+}
+
+// RegisterServices registers both the v1 and the v2 API of s with gs.
+func (s *Server) RegisterServices(gs *grpc.Server) {
+	v1.RegisterSnowflakeServiceServer(gs, s)
+	v2.RegisterSnowflakeServiceServer(gs, s.V2())
+}